@@ -0,0 +1,160 @@
+package WeightedGraph
+
+import (
+	uc "github.com/PlayerR9/lib_units/common"
+)
+
+// builderEdge is an edge recorded by a GraphBuilder, referring to its
+// endpoints by their index in the builder.
+type builderEdge struct {
+	// from is the index of the source vertex.
+	from int
+
+	// to is the index of the destination vertex.
+	to int
+
+	// weight is the weight of the edge.
+	weight float64
+}
+
+// GraphBuilder is a builder for graphs whose vertices and edges are not all
+// known up front. The zero value is ready to use.
+type GraphBuilder[T uc.Objecter] struct {
+	// vertices are the vertices added so far.
+	vertices []T
+
+	// edges are the edges added so far.
+	edges []builderEdge
+
+	// keyFunc is the function that gives the key of a vertex, if any.
+	keyFunc func(T) string
+
+	// indices maps the key of each vertex to its index. Only used if keyFunc
+	// is set.
+	indices map[string]int
+}
+
+// SetKeyFunc sets the function that gives the key of a vertex. When set,
+// vertices are looked up in an internal map instead of being scanned, which
+// turns AddVertex and AddEdge from O(n) into O(1). The key function is also
+// set on the built graphs and is kept by Reset.
+//
+// Two vertices must have the same key if and only if they are equal according
+// to their Equals method.
+//
+// Parameters:
+//   - f: the key function. If nil, the linear scan is used.
+func (b *GraphBuilder[T]) SetKeyFunc(f func(T) string) {
+	b.keyFunc = f
+	b.indices = nil
+
+	if f == nil {
+		return
+	}
+
+	b.indices = make(map[string]int, len(b.vertices))
+
+	for i, v := range b.vertices {
+		b.indices[f(v)] = i
+	}
+}
+
+// add adds a vertex to the builder if no equal vertex was already added.
+//
+// Parameters:
+//   - v: the vertex to add.
+//
+// Returns:
+//   - int: the index of the vertex in the builder.
+func (b *GraphBuilder[T]) add(v T) int {
+	var key string
+
+	if b.keyFunc != nil {
+		key = b.keyFunc(v)
+
+		i, ok := b.indices[key]
+		if ok {
+			return i
+		}
+	} else {
+		for i, x := range b.vertices {
+			if x.Equals(v) {
+				return i
+			}
+		}
+	}
+
+	i := len(b.vertices)
+	b.vertices = append(b.vertices, v)
+
+	if b.keyFunc != nil {
+		b.indices[key] = i
+	}
+
+	return i
+}
+
+// AddVertex adds a vertex to the builder. Does nothing if an equal vertex was
+// already added.
+//
+// Parameters:
+//   - v: the vertex to add.
+func (b *GraphBuilder[T]) AddVertex(v T) {
+	b.add(v)
+}
+
+// AddEdge adds an edge to the builder, adding its endpoints as vertices if
+// needed. If the same edge is added more than once, the last weight wins.
+//
+// Parameters:
+//   - from: the source vertex.
+//   - to: the destination vertex.
+//   - w: the weight of the edge.
+func (b *GraphBuilder[T]) AddEdge(from, to T, w float64) {
+	i := b.add(from)
+	j := b.add(to)
+
+	b.edges = append(b.edges, builderEdge{
+		from:   i,
+		to:     j,
+		weight: w,
+	})
+}
+
+// Build creates a new directed graph with the vertices and edges added so
+// far, in the order they were added.
+//
+// It resets the builder after creating the graph.
+//
+// Returns:
+//   - *Graph: the new graph.
+func (b *GraphBuilder[T]) Build() *Graph[T] {
+	n := len(b.vertices)
+
+	g := &Graph[T]{
+		vertices: make([]T, n),
+		edges:    makeMatrix(n),
+	}
+
+	copy(g.vertices, b.vertices)
+
+	for _, e := range b.edges {
+		w := e.weight
+		g.edges[e.from][e.to] = &w
+	}
+
+	g.SetKeyFunc(b.keyFunc)
+
+	b.Reset()
+
+	return g
+}
+
+// Reset removes all vertices and edges from the builder. The key function,
+// if any, is kept.
+func (b *GraphBuilder[T]) Reset() {
+	b.vertices = nil
+	b.edges = nil
+
+	b.SetKeyFunc(b.keyFunc)
+}
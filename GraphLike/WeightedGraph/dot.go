@@ -0,0 +1,84 @@
+package WeightedGraph
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ToDOT returns the Graphviz DOT representation of the graph. Every vertex is
+// emitted as a node and every edge is labeled with its weight. Undirected
+// graphs are emitted as a "graph" with each edge written once; directed ones
+// as a "digraph".
+//
+// Parameters:
+//   - label: the function that gives the name of each vertex. If nil,
+//     fmt.Sprintf("%v", vertex) is used.
+//
+// Returns:
+//   - string: the DOT representation of the graph.
+func (g *Graph[T]) ToDOT(label func(T) string) string {
+	if label == nil {
+		label = func(v T) string {
+			return fmt.Sprintf("%v", v)
+		}
+	}
+
+	names := make([]string, 0, len(g.vertices))
+
+	for _, v := range g.vertices {
+		names = append(names, dotQuote(label(v)))
+	}
+
+	kind, arrow := "digraph", "->"
+	if g.isUndirected {
+		kind, arrow = "graph", "--"
+	}
+
+	var builder strings.Builder
+
+	builder.WriteString(kind)
+	builder.WriteString(" {\n")
+
+	for _, name := range names {
+		builder.WriteString("\t")
+		builder.WriteString(name)
+		builder.WriteString(";\n")
+	}
+
+	for i, row := range g.edges {
+		for j, w := range row {
+			if w == nil || (g.isUndirected && j < i) {
+				continue
+			}
+
+			builder.WriteString("\t")
+			builder.WriteString(names[i])
+			builder.WriteString(" ")
+			builder.WriteString(arrow)
+			builder.WriteString(" ")
+			builder.WriteString(names[j])
+			builder.WriteString(" [label=")
+			builder.WriteString(dotQuote(strconv.FormatFloat(*w, 'g', -1, 64)))
+			builder.WriteString("];\n")
+		}
+	}
+
+	builder.WriteString("}\n")
+
+	return builder.String()
+}
+
+// dotQuote quotes the given string as a DOT identifier.
+//
+// Parameters:
+//   - s: the string to quote.
+//
+// Returns:
+//   - string: the quoted string.
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+
+	return `"` + s + `"`
+}
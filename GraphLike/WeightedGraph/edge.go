@@ -0,0 +1,98 @@
+package WeightedGraph
+
+import (
+	"slices"
+	"strings"
+
+	uc "github.com/PlayerR9/lib_units/common"
+)
+
+// Edge represents an edge of a graph.
+type Edge[T uc.Objecter] struct {
+	// From is the source vertex.
+	From T
+
+	// To is the destination vertex.
+	To T
+
+	// Weight is the weight of the edge.
+	Weight float64
+}
+
+// EdgeList returns the edges of the graph in row-major order of the edge
+// matrix. On an undirected graph, each edge is listed once, with From being
+// the vertex that comes first in the graph.
+//
+// Returns:
+//   - []Edge: the edges of the graph.
+func (g *Graph[T]) EdgeList() []Edge[T] {
+	var edges []Edge[T]
+
+	for i, row := range g.edges {
+		for j, w := range row {
+			if w == nil || (g.isUndirected && j < i) {
+				continue
+			}
+
+			edges = append(edges, Edge[T]{
+				From:   g.vertices[i],
+				To:     g.vertices[j],
+				Weight: *w,
+			})
+		}
+	}
+
+	return edges
+}
+
+// WeightedEdge represents an edge of an adjacency list, whose source vertex
+// is given by the list it belongs to.
+type WeightedEdge[T uc.Objecter] struct {
+	// To is the destination vertex.
+	To T
+
+	// Weight is the weight of the edge.
+	Weight float64
+}
+
+// NewGraphFromAdjacency creates a new directed graph from the given adjacency
+// list. Every key and every destination becomes a vertex, even if it only
+// appears as a destination.
+//
+// Since map iteration order is random, the keys are added sorted by their
+// String representation, followed by the vertices that only appear as
+// destinations, in the order they are encountered.
+//
+// Parameters:
+//   - adj: the adjacency list, mapping each vertex to its outgoing edges.
+//
+// Returns:
+//   - *Graph: the new graph.
+func NewGraphFromAdjacency[T interface {
+	uc.Objecter
+	comparable
+}](adj map[T][]WeightedEdge[T]) *Graph[T] {
+	keys := make([]T, 0, len(adj))
+
+	for k := range adj {
+		keys = append(keys, k)
+	}
+
+	slices.SortFunc(keys, func(a, b T) int {
+		return strings.Compare(a.String(), b.String())
+	})
+
+	var builder GraphBuilder[T]
+
+	for _, k := range keys {
+		builder.AddVertex(k)
+	}
+
+	for _, k := range keys {
+		for _, e := range adj[k] {
+			builder.AddEdge(k, e.To, e.Weight)
+		}
+	}
+
+	return builder.Build()
+}
@@ -0,0 +1,95 @@
+package WeightedGraph
+
+// ErrVertexNotFound is an error that is returned when a vertex is not
+// in the graph.
+type ErrVertexNotFound struct{}
+
+// Error implements the error interface.
+//
+// Message: "vertex not found"
+func (e *ErrVertexNotFound) Error() string {
+	return "vertex not found"
+}
+
+// NewErrVertexNotFound creates a new ErrVertexNotFound error.
+//
+// Returns:
+//   - *ErrVertexNotFound: the new error.
+func NewErrVertexNotFound() *ErrVertexNotFound {
+	return &ErrVertexNotFound{}
+}
+
+// ErrNegativeCycle is an error that is returned when the graph contains a
+// cycle whose total weight is negative.
+type ErrNegativeCycle struct{}
+
+// Error implements the error interface.
+//
+// Message: "graph contains a negative cycle"
+func (e *ErrNegativeCycle) Error() string {
+	return "graph contains a negative cycle"
+}
+
+// NewErrNegativeCycle creates a new ErrNegativeCycle error.
+//
+// Returns:
+//   - *ErrNegativeCycle: the new error.
+func NewErrNegativeCycle() *ErrNegativeCycle {
+	return &ErrNegativeCycle{}
+}
+
+// ErrDisconnected is an error that is returned when the graph is not
+// connected.
+type ErrDisconnected struct{}
+
+// Error implements the error interface.
+//
+// Message: "graph is not connected"
+func (e *ErrDisconnected) Error() string {
+	return "graph is not connected"
+}
+
+// NewErrDisconnected creates a new ErrDisconnected error.
+//
+// Returns:
+//   - *ErrDisconnected: the new error.
+func NewErrDisconnected() *ErrDisconnected {
+	return &ErrDisconnected{}
+}
+
+// ErrCycle is an error that is returned when the graph contains a cycle.
+type ErrCycle struct{}
+
+// Error implements the error interface.
+//
+// Message: "graph contains a cycle"
+func (e *ErrCycle) Error() string {
+	return "graph contains a cycle"
+}
+
+// NewErrCycle creates a new ErrCycle error.
+//
+// Returns:
+//   - *ErrCycle: the new error.
+func NewErrCycle() *ErrCycle {
+	return &ErrCycle{}
+}
+
+// ErrUndirected is an error that is returned when an operation that only
+// makes sense on directed graphs is used on an undirected one.
+type ErrUndirected struct{}
+
+// Error implements the error interface.
+//
+// Message: "operation requires a directed graph"
+func (e *ErrUndirected) Error() string {
+	return "operation requires a directed graph"
+}
+
+// NewErrUndirected creates a new ErrUndirected error.
+//
+// Returns:
+//   - *ErrUndirected: the new error.
+func NewErrUndirected() *ErrUndirected {
+	return &ErrUndirected{}
+}
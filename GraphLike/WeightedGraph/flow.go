@@ -0,0 +1,121 @@
+package WeightedGraph
+
+import (
+	"errors"
+	"math"
+
+	uc "github.com/PlayerR9/lib_units/common"
+)
+
+// MaxFlow computes the maximum flow from the source vertex to the sink vertex
+// using the Edmonds-Karp algorithm, where the weight of each edge is its
+// capacity. Negative weights are treated as a capacity of zero. The graph is
+// not modified.
+//
+// Parameters:
+//   - source: the source vertex.
+//   - sink: the sink vertex.
+//
+// Returns:
+//   - float64: the value of the maximum flow.
+//   - error: an error if either vertex is not in the graph or if they are the
+//     same vertex.
+//
+// Errors:
+//   - *uc.ErrInvalidParameter: if either vertex is not in the graph or if the
+//     sink is the source.
+func (g *Graph[T]) MaxFlow(source, sink T) (float64, error) {
+	s := g.IndexOf(source)
+	if s == -1 {
+		return 0, uc.NewErrInvalidParameter("source", NewErrVertexNotFound())
+	}
+
+	t := g.IndexOf(sink)
+	if t == -1 {
+		return 0, uc.NewErrInvalidParameter("sink", NewErrVertexNotFound())
+	} else if s == t {
+		return 0, uc.NewErrInvalidParameter("sink", errors.New("must be different from the source"))
+	}
+
+	n := len(g.vertices)
+
+	residual := make([][]float64, n)
+
+	for i, row := range g.edges {
+		residual[i] = make([]float64, n)
+
+		for j, w := range row {
+			if w != nil && *w > 0 {
+				residual[i][j] = *w
+			}
+		}
+	}
+
+	var flow float64
+
+	for {
+		prev := augmentingPath(residual, s, t)
+		if prev == nil {
+			break
+		}
+
+		bottleneck := math.Inf(1)
+
+		for v := t; v != s; v = prev[v] {
+			bottleneck = math.Min(bottleneck, residual[prev[v]][v])
+		}
+
+		for v := t; v != s; v = prev[v] {
+			residual[prev[v]][v] -= bottleneck
+			residual[v][prev[v]] += bottleneck
+		}
+
+		flow += bottleneck
+	}
+
+	return flow, nil
+}
+
+// augmentingPath finds the shortest path with residual capacity from s to t
+// with a BFS.
+//
+// Parameters:
+//   - residual: the residual capacities.
+//   - s: the index of the source.
+//   - t: the index of the sink.
+//
+// Returns:
+//   - []int: the predecessor of each vertex on the path, or nil if t is not
+//     reachable.
+func augmentingPath(residual [][]float64, s, t int) []int {
+	prev := make([]int, len(residual))
+
+	for i := range prev {
+		prev[i] = -1
+	}
+
+	prev[s] = s
+
+	queue := []int{s}
+
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+
+		for v, c := range residual[u] {
+			if c <= 0 || prev[v] != -1 {
+				continue
+			}
+
+			prev[v] = u
+
+			if v == t {
+				return prev
+			}
+
+			queue = append(queue, v)
+		}
+	}
+
+	return nil
+}
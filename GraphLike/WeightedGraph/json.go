@@ -0,0 +1,130 @@
+package WeightedGraph
+
+import (
+	"encoding/json"
+	"fmt"
+
+	uc "github.com/PlayerR9/lib_units/common"
+)
+
+// graphJSON is the JSON representation of a graph.
+type graphJSON[V any] struct {
+	// Vertices are the vertices of the graph.
+	Vertices []V `json:"vertices"`
+
+	// Edges is the edge matrix, where missing edges are null.
+	Edges [][]*float64 `json:"edges"`
+
+	// IsUndirected is true if the graph is undirected.
+	IsUndirected bool `json:"undirected,omitempty"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+//
+// The vertices are encoded with encoding/json, so T must be JSON-encodable.
+// Missing edges are encoded as null.
+func (g *Graph[T]) MarshalJSON() ([]byte, error) {
+	data := graphJSON[T]{
+		Vertices:     g.vertices,
+		Edges:        g.edges,
+		IsUndirected: g.isUndirected,
+	}
+
+	return json.Marshal(data)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+//
+// Each vertex is decoded into the zero value of T; use NewGraphFromJSON when
+// T needs to be constructed in a specific way, such as interface types.
+//
+// The key function set with SetKeyFunc, if any, is kept and the index is
+// rebuilt for the decoded vertices.
+func (g *Graph[T]) UnmarshalJSON(data []byte) error {
+	other, err := NewGraphFromJSON(data, func() T {
+		return *new(T)
+	})
+	if err != nil {
+		return err
+	}
+
+	keyFunc := g.keyFunc
+
+	*g = *other
+
+	g.SetKeyFunc(keyFunc)
+
+	return nil
+}
+
+// NewGraphFromJSON creates a new graph from its JSON representation, as
+// produced by MarshalJSON.
+//
+// Parameters:
+//   - data: the JSON data.
+//   - newT: the function that creates the value each vertex is decoded into.
+//
+// Returns:
+//   - *Graph: the new graph.
+//   - error: an error if the data could not be decoded.
+//
+// Errors:
+//   - *uc.ErrInvalidParameter: if newT is nil.
+//   - any error returned by encoding/json, if the edge matrix does not match
+//     the number of vertices, or if the graph is undirected and the matrix is
+//     not symmetric.
+func NewGraphFromJSON[T uc.Objecter](data []byte, newT func() T) (*Graph[T], error) {
+	if newT == nil {
+		return nil, uc.NewErrNilParameter("newT")
+	}
+
+	var raw graphJSON[json.RawMessage]
+
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(raw.Vertices)
+
+	if len(raw.Edges) != n {
+		return nil, fmt.Errorf("expected %d rows of edges, got %d", n, len(raw.Edges))
+	}
+
+	g := &Graph[T]{
+		vertices:     make([]T, 0, n),
+		edges:        make([][]*float64, 0, n),
+		isUndirected: raw.IsUndirected,
+	}
+
+	for i, elem := range raw.Vertices {
+		v := newT()
+
+		err := json.Unmarshal(elem, &v)
+		if err != nil {
+			return nil, fmt.Errorf("vertex %d: %w", i, err)
+		}
+
+		g.vertices = append(g.vertices, v)
+	}
+
+	for i, row := range raw.Edges {
+		if len(row) != n {
+			return nil, fmt.Errorf("expected %d edges in row %d, got %d", n, i, len(row))
+		}
+
+		g.edges = append(g.edges, row)
+	}
+
+	if g.isUndirected {
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				if !sameWeight(g.edges[i][j], g.edges[j][i]) {
+					return nil, fmt.Errorf("undirected graph has asymmetric edges between %d and %d", i, j)
+				}
+			}
+		}
+	}
+
+	return g, nil
+}
@@ -0,0 +1,587 @@
+package WeightedGraph
+
+import "math"
+
+// OutDegree returns the number of edges leaving the given vertex.
+//
+// Parameters:
+//   - v: the vertex.
+//
+// Returns:
+//   - int: the out-degree, or -1 if the vertex is not in the graph.
+func (g *Graph[T]) OutDegree(v T) int {
+	i := g.IndexOf(v)
+	if i == -1 {
+		return -1
+	}
+
+	return g.outDegree(i)
+}
+
+// InDegree returns the number of edges entering the given vertex.
+//
+// Parameters:
+//   - v: the vertex.
+//
+// Returns:
+//   - int: the in-degree, or -1 if the vertex is not in the graph.
+func (g *Graph[T]) InDegree(v T) int {
+	j := g.IndexOf(v)
+	if j == -1 {
+		return -1
+	}
+
+	return g.inDegree(j)
+}
+
+// outDegree counts the non-nil entries in the row of the given index.
+//
+// Parameters:
+//   - i: the index of the vertex.
+//
+// Returns:
+//   - int: the out-degree.
+func (g *Graph[T]) outDegree(i int) int {
+	var count int
+
+	for _, w := range g.edges[i] {
+		if w != nil {
+			count++
+		}
+	}
+
+	return count
+}
+
+// inDegree counts the non-nil entries in the column of the given index.
+//
+// Parameters:
+//   - j: the index of the vertex.
+//
+// Returns:
+//   - int: the in-degree.
+func (g *Graph[T]) inDegree(j int) int {
+	var count int
+
+	for _, row := range g.edges {
+		if row[j] != nil {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Eccentricity returns the greatest shortest-path distance from the given
+// vertex to any other vertex.
+//
+// Parameters:
+//   - v: the vertex.
+//
+// Returns:
+//   - float64: the eccentricity of the vertex.
+//   - bool: false if the vertex is not in the graph, if some vertex is not
+//     reachable from it or if a negative cycle is reachable from it.
+func (g *Graph[T]) Eccentricity(v T) (float64, bool) {
+	i := g.IndexOf(v)
+	if i == -1 {
+		return 0, false
+	}
+
+	dist, _, err := g.bellmanFord(i)
+	if err != nil {
+		return 0, false
+	}
+
+	return eccentricity(dist)
+}
+
+// Diameter returns the greatest shortest-path distance between any pair of
+// vertices.
+//
+// Returns:
+//   - float64: the diameter of the graph.
+//   - bool: false if the graph is empty, if some vertex is not reachable from
+//     another or if the graph contains a negative cycle.
+func (g *Graph[T]) Diameter() (float64, bool) {
+	if len(g.vertices) == 0 {
+		return 0, false
+	}
+
+	dist, err := g.AllPairsShortestPaths()
+	if err != nil {
+		return 0, false
+	}
+
+	var diameter float64
+
+	for i, row := range dist {
+		ecc, ok := eccentricity(row)
+		if !ok {
+			return 0, false
+		}
+
+		if i == 0 || ecc > diameter {
+			diameter = ecc
+		}
+	}
+
+	return diameter, true
+}
+
+// eccentricity returns the greatest of the given distances.
+//
+// Parameters:
+//   - dist: the distances from a vertex to every vertex.
+//
+// Returns:
+//   - float64: the greatest distance.
+//   - bool: false if any distance is infinite, otherwise true.
+func eccentricity(dist []float64) (float64, bool) {
+	var ecc float64
+
+	for j, d := range dist {
+		if math.IsInf(d, 1) {
+			return 0, false
+		}
+
+		if j == 0 || d > ecc {
+			ecc = d
+		}
+	}
+
+	return ecc, true
+}
+
+// DegreeCentrality computes the degree centrality of every vertex, that is,
+// the number of vertices it is connected to divided by n-1. On a directed
+// graph, both incoming and outgoing edges are counted, so values can exceed
+// 1. Self-loops are ignored.
+//
+// Returns:
+//   - map[int]float64: the centrality of each vertex, keyed by its index. All
+//     values are 0 if the graph has fewer than two vertices.
+func (g *Graph[T]) DegreeCentrality() map[int]float64 {
+	n := len(g.vertices)
+
+	centrality := make(map[int]float64, n)
+
+	for i := range g.vertices {
+		centrality[i] = 0
+	}
+
+	if n < 2 {
+		return centrality
+	}
+
+	for i, row := range g.edges {
+		for j, w := range row {
+			if w == nil || i == j {
+				continue
+			}
+
+			centrality[i]++
+
+			if !g.isUndirected {
+				centrality[j]++
+			}
+		}
+	}
+
+	for i, c := range centrality {
+		centrality[i] = c / float64(n-1)
+	}
+
+	return centrality
+}
+
+// BetweennessCentrality computes the betweenness centrality of every vertex
+// using Brandes' algorithm, that is, the fraction of shortest paths between
+// other pairs of vertices that go through it. Edge weights are used as
+// distances, so they must not be negative.
+//
+// Values are normalized by (n-1)(n-2).
+//
+// Returns:
+//   - map[int]float64: the centrality of each vertex, keyed by its index. All
+//     values are 0 if the graph has fewer than three vertices.
+func (g *Graph[T]) BetweennessCentrality() map[int]float64 {
+	n := len(g.vertices)
+
+	centrality := make(map[int]float64, n)
+
+	for i := range g.vertices {
+		centrality[i] = 0
+	}
+
+	if n < 3 {
+		return centrality
+	}
+
+	for s := range g.vertices {
+		order, preds, sigma := g.countShortestPaths(s)
+
+		delta := make([]float64, n)
+
+		for k := len(order) - 1; k >= 0; k-- {
+			w := order[k]
+
+			for _, v := range preds[w] {
+				delta[v] += sigma[v] / sigma[w] * (1 + delta[w])
+			}
+
+			if w != s {
+				centrality[w] += delta[w]
+			}
+		}
+	}
+
+	scale := 1 / float64((n-1)*(n-2))
+
+	for i, c := range centrality {
+		centrality[i] = c * scale
+	}
+
+	return centrality
+}
+
+// countShortestPaths runs Dijkstra's algorithm from the given index while
+// counting the number of shortest paths to each vertex.
+//
+// Parameters:
+//   - src: the index of the source vertex.
+//
+// Returns:
+//   - []int: the reached vertices, in non-decreasing order of distance.
+//   - [][]int: the predecessors of each vertex on its shortest paths.
+//   - []float64: the number of shortest paths to each vertex.
+func (g *Graph[T]) countShortestPaths(src int) ([]int, [][]int, []float64) {
+	n := len(g.vertices)
+
+	dist := make([]float64, n)
+	visited := make([]bool, n)
+	preds := make([][]int, n)
+	sigma := make([]float64, n)
+
+	for i := range dist {
+		dist[i] = math.Inf(1)
+	}
+
+	dist[src] = 0
+	sigma[src] = 1
+
+	var order []int
+
+	for {
+		u := -1
+
+		for i, d := range dist {
+			if visited[i] || math.IsInf(d, 1) {
+				continue
+			}
+
+			if u == -1 || d < dist[u] {
+				u = i
+			}
+		}
+
+		if u == -1 {
+			break
+		}
+
+		visited[u] = true
+		order = append(order, u)
+
+		for v, w := range g.edges[u] {
+			if w == nil || visited[v] {
+				continue
+			}
+
+			alt := dist[u] + *w
+
+			switch {
+			case alt < dist[v]:
+				dist[v] = alt
+				sigma[v] = sigma[u]
+				preds[v] = []int{u}
+			case alt == dist[v]:
+				sigma[v] += sigma[u]
+				preds[v] = append(preds[v], u)
+			}
+		}
+	}
+
+	return order, preds, sigma
+}
+
+// Normalize creates a new graph whose weights are divided by the greatest
+// absolute weight of the graph, so that they all lie in [-1, 1]. The original
+// graph is not modified.
+//
+// Returns:
+//   - *Graph: the normalized graph, or an unchanged copy if the graph has no
+//     edges or all of its weights are zero.
+func (g *Graph[T]) Normalize() *Graph[T] {
+	normalized := g.Subgraph(nil)
+
+	var maxAbs float64
+
+	for _, row := range normalized.edges {
+		for _, w := range row {
+			if w != nil {
+				maxAbs = math.Max(maxAbs, math.Abs(*w))
+			}
+		}
+	}
+
+	if maxAbs == 0 {
+		return normalized
+	}
+
+	for _, row := range normalized.edges {
+		for _, w := range row {
+			if w != nil {
+				*w /= maxAbs
+			}
+		}
+	}
+
+	return normalized
+}
+
+// VertexCount returns the number of vertices in the graph.
+//
+// Returns:
+//   - int: the number of vertices.
+func (g *Graph[T]) VertexCount() int {
+	return len(g.vertices)
+}
+
+// EdgeCount returns the number of edges in the graph. On an undirected graph,
+// each edge is counted once even though it is stored in both directions.
+//
+// Returns:
+//   - int: the number of edges.
+func (g *Graph[T]) EdgeCount() int {
+	var count int
+
+	for i, row := range g.edges {
+		for j, w := range row {
+			if w != nil && (!g.isUndirected || j >= i) {
+				count++
+			}
+		}
+	}
+
+	return count
+}
+
+// Density returns the ratio of the edges in the graph to the edges it could
+// have, that is, n(n-1) for a directed graph and n(n-1)/2 for an undirected
+// one. Self-loops are not counted.
+//
+// Returns:
+//   - float64: the density, or 0 if the graph has fewer than two vertices.
+func (g *Graph[T]) Density() float64 {
+	n := g.VertexCount()
+	if n < 2 {
+		return 0
+	}
+
+	edges := g.EdgeCount()
+
+	for i, row := range g.edges {
+		if row[i] != nil {
+			edges--
+		}
+	}
+
+	possible := float64(n * (n - 1))
+	if g.isUndirected {
+		possible /= 2
+	}
+
+	return float64(edges) / possible
+}
+
+// IsolatedVertices returns the vertices that have neither incoming nor
+// outgoing edges.
+//
+// Returns:
+//   - []T: the isolated vertices, in the order they appear in the graph.
+func (g *Graph[T]) IsolatedVertices() []T {
+	var isolated []T
+
+	for i, v := range g.vertices {
+		if g.outDegree(i) == 0 && g.inDegree(i) == 0 {
+			isolated = append(isolated, v)
+		}
+	}
+
+	return isolated
+}
+
+// PageRank computes the PageRank of every vertex, where each vertex gives its
+// rank to its successors in proportion to the weights of the edges leading to
+// them. Edges with a non-positive weight are ignored and vertices without
+// any other outgoing edge spread their rank uniformly over all vertices.
+//
+// Parameters:
+//   - damping: the probability of following an edge, usually 0.85. It should
+//     be in [0, 1].
+//   - iterations: the number of iterations to perform.
+//
+// Returns:
+//   - map[int]float64: the rank of each vertex, keyed by its index. The ranks
+//     sum up to 1.
+func (g *Graph[T]) PageRank(damping float64, iterations int) map[int]float64 {
+	n := len(g.vertices)
+
+	ranks := make(map[int]float64, n)
+	if n == 0 {
+		return ranks
+	}
+
+	outWeights := make([]float64, n)
+
+	for i, row := range g.edges {
+		for _, w := range row {
+			if w != nil && *w > 0 {
+				outWeights[i] += *w
+			}
+		}
+	}
+
+	rank := make([]float64, n)
+
+	for i := range rank {
+		rank[i] = 1 / float64(n)
+	}
+
+	for k := 0; k < iterations; k++ {
+		var dangling float64
+
+		for i, r := range rank {
+			if outWeights[i] == 0 {
+				dangling += r
+			}
+		}
+
+		base := (1-damping)/float64(n) + damping*dangling/float64(n)
+
+		next := make([]float64, n)
+
+		for i := range next {
+			next[i] = base
+		}
+
+		for i, row := range g.edges {
+			if outWeights[i] == 0 {
+				continue
+			}
+
+			for j, w := range row {
+				if w != nil && *w > 0 {
+					next[j] += damping * rank[i] * *w / outWeights[i]
+				}
+			}
+		}
+
+		rank = next
+	}
+
+	for i, r := range rank {
+		ranks[i] = r
+	}
+
+	return ranks
+}
+
+// WeightStats computes statistics over the weights of the edges of the graph.
+// On an undirected graph, each edge is counted once.
+//
+// Returns:
+//   - float64: the smallest weight.
+//   - float64: the greatest weight.
+//   - float64: the mean weight.
+//   - int: the number of edges. If 0, all the statistics are 0.
+func (g *Graph[T]) WeightStats() (float64, float64, float64, int) {
+	var minimum, maximum, total float64
+	var count int
+
+	for _, e := range g.EdgeList() {
+		if count == 0 || e.Weight < minimum {
+			minimum = e.Weight
+		}
+
+		if count == 0 || e.Weight > maximum {
+			maximum = e.Weight
+		}
+
+		total += e.Weight
+		count++
+	}
+
+	if count == 0 {
+		return 0, 0, 0, 0
+	}
+
+	return minimum, maximum, total / float64(count), count
+}
+
+// MaxEdge returns the edge with the greatest weight. Ties are broken by the
+// order of the edges in EdgeList.
+//
+// Returns:
+//   - T: the source vertex of the edge.
+//   - T: the destination vertex of the edge.
+//   - float64: the weight of the edge.
+//   - bool: false if the graph has no edges, otherwise true.
+func (g *Graph[T]) MaxEdge() (T, T, float64, bool) {
+	return g.extremeEdge(func(a, b float64) bool {
+		return a > b
+	})
+}
+
+// MinEdge returns the edge with the smallest weight. Ties are broken by the
+// order of the edges in EdgeList.
+//
+// Returns:
+//   - T: the source vertex of the edge.
+//   - T: the destination vertex of the edge.
+//   - float64: the weight of the edge.
+//   - bool: false if the graph has no edges, otherwise true.
+func (g *Graph[T]) MinEdge() (T, T, float64, bool) {
+	return g.extremeEdge(func(a, b float64) bool {
+		return a < b
+	})
+}
+
+// extremeEdge returns the first edge whose weight is better than the weight
+// of every other edge.
+//
+// Parameters:
+//   - better: the function that tells whether the first weight is better
+//     than the second one.
+//
+// Returns:
+//   - T: the source vertex of the edge.
+//   - T: the destination vertex of the edge.
+//   - float64: the weight of the edge.
+//   - bool: false if the graph has no edges, otherwise true.
+func (g *Graph[T]) extremeEdge(better func(a, b float64) bool) (T, T, float64, bool) {
+	edges := g.EdgeList()
+	if len(edges) == 0 {
+		return *new(T), *new(T), 0, false
+	}
+
+	best := edges[0]
+
+	for _, e := range edges[1:] {
+		if better(e.Weight, best.Weight) {
+			best = e
+		}
+	}
+
+	return best.From, best.To, best.Weight, true
+}
@@ -0,0 +1,114 @@
+package WeightedGraph
+
+import "math"
+
+// undirectedEdge returns the lightest weight of the edges between the given
+// indices, regardless of their direction.
+//
+// Parameters:
+//   - i: the index of the first vertex.
+//   - j: the index of the second vertex.
+//
+// Returns:
+//   - float64: the weight of the edge.
+//   - bool: true if there is an edge in either direction, otherwise false.
+func (g *Graph[T]) undirectedEdge(i, j int) (float64, bool) {
+	a := g.edges[i][j]
+	b := g.edges[j][i]
+
+	switch {
+	case a == nil && b == nil:
+		return 0, false
+	case a == nil:
+		return *b, true
+	case b == nil:
+		return *a, true
+	default:
+		return math.Min(*a, *b), true
+	}
+}
+
+// MST computes a minimum spanning tree of the graph using Prim's algorithm.
+// Edges are treated as undirected; when both directions exist, the lightest
+// one is used.
+//
+// Returns:
+//   - *Graph: a new undirected graph with the same vertices and only the
+//     edges of the spanning tree.
+//   - float64: the total weight of the spanning tree.
+//   - error: an error if the graph is not connected.
+//
+// Errors:
+//   - *ErrDisconnected: if no spanning tree exists.
+func (g *Graph[T]) MST() (*Graph[T], float64, error) {
+	n := len(g.vertices)
+
+	mst := &Graph[T]{
+		vertices:     make([]T, n),
+		edges:        makeMatrix(n),
+		isUndirected: true,
+	}
+
+	copy(mst.vertices, g.vertices)
+	mst.SetKeyFunc(g.keyFunc)
+
+	if n == 0 {
+		return mst, 0, nil
+	}
+
+	inTree := make([]bool, n)
+	cost := make([]float64, n)
+	parent := make([]int, n)
+
+	for i := range cost {
+		cost[i] = math.Inf(1)
+		parent[i] = -1
+	}
+
+	cost[0] = 0
+
+	var total float64
+
+	for k := 0; k < n; k++ {
+		u := -1
+
+		for i, c := range cost {
+			if inTree[i] || math.IsInf(c, 1) {
+				continue
+			}
+
+			if u == -1 || c < cost[u] {
+				u = i
+			}
+		}
+
+		if u == -1 {
+			return nil, 0, NewErrDisconnected()
+		}
+
+		inTree[u] = true
+
+		if p := parent[u]; p != -1 {
+			w1, w2 := cost[u], cost[u]
+
+			mst.edges[p][u] = &w1
+			mst.edges[u][p] = &w2
+
+			total += cost[u]
+		}
+
+		for v := range g.vertices {
+			if inTree[v] {
+				continue
+			}
+
+			w, ok := g.undirectedEdge(u, v)
+			if ok && w < cost[v] {
+				cost[v] = w
+				parent[v] = u
+			}
+		}
+	}
+
+	return mst, total, nil
+}
@@ -0,0 +1,508 @@
+package WeightedGraph
+
+import (
+	"math"
+	"slices"
+
+	uc "github.com/PlayerR9/lib_units/common"
+)
+
+// dijkstra computes the shortest distances from the vertex at the given
+// index to every other vertex of the graph.
+//
+// Parameters:
+//   - src: the index of the source vertex.
+//   - skip: the function that tells whether the edge between the given
+//     indices must be ignored. If nil, no edge is ignored.
+//
+// Returns:
+//   - []float64: the distances, +Inf for unreachable vertices.
+//   - []int: the predecessor of each vertex, -1 if there is none.
+func (g *Graph[T]) dijkstra(src int, skip func(u, v int) bool) ([]float64, []int) {
+	n := len(g.vertices)
+
+	dist := make([]float64, n)
+	prev := make([]int, n)
+	visited := make([]bool, n)
+
+	for i := range dist {
+		dist[i] = math.Inf(1)
+		prev[i] = -1
+	}
+
+	dist[src] = 0
+
+	for {
+		u := -1
+
+		for i, d := range dist {
+			if visited[i] || math.IsInf(d, 1) {
+				continue
+			}
+
+			if u == -1 || d < dist[u] {
+				u = i
+			}
+		}
+
+		if u == -1 {
+			break
+		}
+
+		visited[u] = true
+
+		for v, w := range g.edges[u] {
+			if w == nil || visited[v] || (skip != nil && skip(u, v)) {
+				continue
+			}
+
+			alt := dist[u] + *w
+			if alt < dist[v] {
+				dist[v] = alt
+				prev[v] = u
+			}
+		}
+	}
+
+	return dist, prev
+}
+
+// pathIndices reconstructs the indices of the path ending at the given index
+// from the predecessors computed by a shortest path algorithm.
+//
+// Parameters:
+//   - prev: the predecessor of each vertex, -1 if there is none.
+//   - to: the index of the last vertex of the path.
+//
+// Returns:
+//   - []int: the indices of the path, in order.
+func pathIndices(prev []int, to int) []int {
+	var indices []int
+
+	for at := to; at != -1; at = prev[at] {
+		indices = append(indices, at)
+	}
+
+	slices.Reverse(indices)
+
+	return indices
+}
+
+// makePath reconstructs the path ending at the given index from the
+// predecessors computed by a shortest path algorithm.
+//
+// Parameters:
+//   - prev: the predecessor of each vertex, -1 if there is none.
+//   - to: the index of the last vertex of the path.
+//
+// Returns:
+//   - []T: the vertices of the path, in order.
+func (g *Graph[T]) makePath(prev []int, to int) []T {
+	return g.toVertices(pathIndices(prev, to))
+}
+
+// ShortestPath finds the shortest path between the given vertices using
+// Dijkstra's algorithm.
+//
+// Negative weights give undefined results; use ShortestPathBF instead.
+//
+// Parameters:
+//   - from: the source vertex.
+//   - to: the destination vertex.
+//
+// Returns:
+//   - []T: the vertices of the path, from source to destination.
+//   - float64: the total cost of the path.
+//   - bool: true if the path exists, otherwise false.
+func (g *Graph[T]) ShortestPath(from, to T) ([]T, float64, bool) {
+	i := g.IndexOf(from)
+	j := g.IndexOf(to)
+
+	if i == -1 || j == -1 {
+		return nil, 0, false
+	}
+
+	dist, prev := g.dijkstra(i, nil)
+	if math.IsInf(dist[j], 1) {
+		return nil, 0, false
+	}
+
+	return g.makePath(prev, j), dist[j], true
+}
+
+// ShortestPathBF finds the shortest path between the given vertices using
+// the Bellman-Ford algorithm. Unlike ShortestPath, negative weights are
+// allowed.
+//
+// Parameters:
+//   - from: the source vertex.
+//   - to: the destination vertex.
+//
+// Returns:
+//   - []T: the vertices of the path, or nil if to is not reachable.
+//   - float64: the total cost of the path.
+//   - error: an error if either vertex is not in the graph or if a negative
+//     cycle is reachable from the source.
+//
+// Errors:
+//   - *uc.ErrInvalidParameter: if either vertex is not in the graph.
+//   - *ErrNegativeCycle: if a negative cycle is reachable from the source.
+func (g *Graph[T]) ShortestPathBF(from, to T) ([]T, float64, error) {
+	i := g.IndexOf(from)
+	if i == -1 {
+		return nil, 0, uc.NewErrInvalidParameter("from", NewErrVertexNotFound())
+	}
+
+	j := g.IndexOf(to)
+	if j == -1 {
+		return nil, 0, uc.NewErrInvalidParameter("to", NewErrVertexNotFound())
+	}
+
+	dist, prev, err := g.bellmanFord(i)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if math.IsInf(dist[j], 1) {
+		return nil, 0, nil
+	}
+
+	return g.makePath(prev, j), dist[j], nil
+}
+
+// bellmanFord computes the shortest distances from the vertex at the given
+// index to every other vertex of the graph, allowing negative weights.
+//
+// Parameters:
+//   - src: the index of the source vertex.
+//
+// Returns:
+//   - []float64: the distances, +Inf for unreachable vertices.
+//   - []int: the predecessor of each vertex, -1 if there is none.
+//   - error: an error if a negative cycle is reachable from the source.
+//
+// Errors:
+//   - *ErrNegativeCycle: if a negative cycle is reachable from the source.
+func (g *Graph[T]) bellmanFord(src int) ([]float64, []int, error) {
+	n := len(g.vertices)
+
+	dist := make([]float64, n)
+	prev := make([]int, n)
+
+	for k := range dist {
+		dist[k] = math.Inf(1)
+		prev[k] = -1
+	}
+
+	dist[src] = 0
+
+	for k := 0; k < n-1; k++ {
+		changed := g.relax(dist, prev)
+		if !changed {
+			break
+		}
+	}
+
+	if g.relax(dist, prev) {
+		return nil, nil, NewErrNegativeCycle()
+	}
+
+	return dist, prev, nil
+}
+
+// relax performs one relaxation pass over every edge of the graph.
+//
+// Parameters:
+//   - dist: the current distances. It is updated in place.
+//   - prev: the current predecessors. It is updated in place.
+//
+// Returns:
+//   - bool: true if any distance was updated, otherwise false.
+func (g *Graph[T]) relax(dist []float64, prev []int) bool {
+	changed := false
+
+	for u, row := range g.edges {
+		if math.IsInf(dist[u], 1) {
+			continue
+		}
+
+		for v, w := range row {
+			if w == nil {
+				continue
+			}
+
+			alt := dist[u] + *w
+			if alt < dist[v] {
+				dist[v] = alt
+				prev[v] = u
+				changed = true
+			}
+		}
+	}
+
+	return changed
+}
+
+// AllPairsShortestPaths computes the shortest distance between every pair of
+// vertices using the Floyd-Warshall algorithm. Negative weights are allowed.
+//
+// Returns:
+//   - [][]float64: the distance matrix, indexed like the vertices of the
+//     graph, where unreachable pairs are +Inf.
+//   - error: an error if the graph contains a negative cycle.
+//
+// Errors:
+//   - *ErrNegativeCycle: if the graph contains a negative cycle.
+func (g *Graph[T]) AllPairsShortestPaths() ([][]float64, error) {
+	n := len(g.vertices)
+
+	dist := make([][]float64, n)
+
+	for i, row := range g.edges {
+		dist[i] = make([]float64, n)
+
+		for j, w := range row {
+			switch {
+			case w != nil && (i != j || *w < 0):
+				dist[i][j] = *w
+			case i == j:
+				dist[i][j] = 0
+			default:
+				dist[i][j] = math.Inf(1)
+			}
+		}
+	}
+
+	for k := 0; k < n; k++ {
+		for i := 0; i < n; i++ {
+			if math.IsInf(dist[i][k], 1) {
+				continue
+			}
+
+			for j := 0; j < n; j++ {
+				alt := dist[i][k] + dist[k][j]
+				if alt < dist[i][j] {
+					dist[i][j] = alt
+				}
+			}
+		}
+	}
+
+	for i := range dist {
+		if dist[i][i] < 0 {
+			return nil, NewErrNegativeCycle()
+		}
+	}
+
+	return dist, nil
+}
+
+// AStar finds the shortest path between the given vertices using the A*
+// algorithm. The heuristic must never overestimate the real distance to the
+// destination; otherwise the path found might not be the shortest one.
+//
+// As with ShortestPath, negative weights give undefined results.
+//
+// Parameters:
+//   - from: the source vertex.
+//   - to: the destination vertex.
+//   - h: the heuristic estimating the distance from a vertex to the
+//     destination. If nil, the search behaves like ShortestPath.
+//
+// Returns:
+//   - []T: the vertices of the path, from source to destination.
+//   - float64: the total cost of the path.
+//   - bool: true if the path exists, otherwise false.
+func (g *Graph[T]) AStar(from, to T, h func(T) float64) ([]T, float64, bool) {
+	i := g.IndexOf(from)
+	j := g.IndexOf(to)
+
+	if i == -1 || j == -1 {
+		return nil, 0, false
+	}
+
+	if h == nil {
+		h = func(T) float64 {
+			return 0
+		}
+	}
+
+	n := len(g.vertices)
+
+	cost := make([]float64, n)
+	estimate := make([]float64, n)
+	prev := make([]int, n)
+	open := make([]bool, n)
+
+	for k := range cost {
+		cost[k] = math.Inf(1)
+		estimate[k] = math.Inf(1)
+		prev[k] = -1
+	}
+
+	cost[i] = 0
+	estimate[i] = h(from)
+	open[i] = true
+
+	for {
+		u := -1
+
+		for k, ok := range open {
+			if ok && (u == -1 || estimate[k] < estimate[u]) {
+				u = k
+			}
+		}
+
+		if u == -1 {
+			return nil, 0, false
+		}
+
+		if u == j {
+			return g.makePath(prev, j), cost[j], true
+		}
+
+		open[u] = false
+
+		for v, w := range g.edges[u] {
+			if w == nil {
+				continue
+			}
+
+			alt := cost[u] + *w
+			if alt < cost[v] {
+				cost[v] = alt
+				estimate[v] = alt + h(g.vertices[v])
+				prev[v] = u
+				open[v] = true
+			}
+		}
+	}
+}
+
+// KShortestPaths finds up to k loopless paths between the given vertices,
+// from the shortest to the longest, using Yen's algorithm on top of
+// Dijkstra's. As with ShortestPath, negative weights give undefined results.
+//
+// Parameters:
+//   - from: the source vertex.
+//   - to: the destination vertex.
+//   - k: the maximum number of paths to find.
+//
+// Returns:
+//   - [][]T: the paths, sorted by total cost. Fewer than k paths are returned
+//     if there are not enough of them, and none if to is not reachable.
+//   - []float64: the total cost of each path.
+//   - error: an error if either vertex is not in the graph or if k is not
+//     positive.
+//
+// Errors:
+//   - *uc.ErrInvalidParameter: if either vertex is not in the graph or if k
+//     is not positive.
+func (g *Graph[T]) KShortestPaths(from, to T, k int) ([][]T, []float64, error) {
+	if k <= 0 {
+		return nil, nil, uc.NewErrInvalidParameter("k", uc.NewErrGT(0))
+	}
+
+	src := g.IndexOf(from)
+	if src == -1 {
+		return nil, nil, uc.NewErrInvalidParameter("from", NewErrVertexNotFound())
+	}
+
+	dst := g.IndexOf(to)
+	if dst == -1 {
+		return nil, nil, uc.NewErrInvalidParameter("to", NewErrVertexNotFound())
+	}
+
+	dist, prev := g.dijkstra(src, nil)
+	if math.IsInf(dist[dst], 1) {
+		return nil, nil, nil
+	}
+
+	found := [][]int{pathIndices(prev, dst)}
+	costs := []float64{dist[dst]}
+
+	var candidates [][]int
+	var candidateCosts []float64
+
+	for len(found) < k {
+		last := found[len(found)-1]
+
+		for i := 0; i < len(last)-1; i++ {
+			root := last[:i+1]
+
+			bannedEdges := make(map[[2]int]bool)
+
+			for _, path := range found {
+				if len(path) > i+1 && slices.Equal(path[:i+1], root) {
+					bannedEdges[[2]int{path[i], path[i+1]}] = true
+				}
+			}
+
+			bannedVertices := make([]bool, len(g.vertices))
+
+			for _, v := range root[:i] {
+				bannedVertices[v] = true
+			}
+
+			spurDist, spurPrev := g.dijkstra(root[i], func(u, v int) bool {
+				return bannedVertices[u] || bannedVertices[v] || bannedEdges[[2]int{u, v}]
+			})
+			if math.IsInf(spurDist[dst], 1) {
+				continue
+			}
+
+			path := slices.Concat(root[:i], pathIndices(spurPrev, dst))
+
+			if slices.ContainsFunc(found, func(p []int) bool { return slices.Equal(p, path) }) ||
+				slices.ContainsFunc(candidates, func(p []int) bool { return slices.Equal(p, path) }) {
+				continue
+			}
+
+			candidates = append(candidates, path)
+			candidateCosts = append(candidateCosts, g.pathCost(root)+spurDist[dst])
+		}
+
+		if len(candidates) == 0 {
+			break
+		}
+
+		best := 0
+
+		for c, cost := range candidateCosts {
+			if cost < candidateCosts[best] {
+				best = c
+			}
+		}
+
+		found = append(found, candidates[best])
+		costs = append(costs, candidateCosts[best])
+
+		candidates = slices.Delete(candidates, best, best+1)
+		candidateCosts = slices.Delete(candidateCosts, best, best+1)
+	}
+
+	paths := make([][]T, 0, len(found))
+
+	for _, path := range found {
+		paths = append(paths, g.toVertices(path))
+	}
+
+	return paths, costs, nil
+}
+
+// pathCost computes the total weight of the edges along the given path.
+//
+// Parameters:
+//   - path: the indices of the vertices of the path.
+//
+// Returns:
+//   - float64: the total weight.
+func (g *Graph[T]) pathCost(path []int) float64 {
+	var total float64
+
+	for i := 1; i < len(path); i++ {
+		total += *g.edges[path[i-1]][path[i]]
+	}
+
+	return total
+}
@@ -0,0 +1,584 @@
+package WeightedGraph
+
+import (
+	"context"
+
+	uc "github.com/PlayerR9/lib_units/common"
+)
+
+// TopologicalSort sorts the vertices of the graph so that every vertex comes
+// before the vertices it has an edge to. Weights are ignored and ties are
+// broken by the order of the vertices in the graph: among the vertices whose
+// predecessors are all sorted, the one that comes first is picked next.
+//
+// Only directed graphs can be sorted: an undirected graph stores every edge
+// in both directions, so it is rejected up front.
+//
+// Returns:
+//   - []T: the sorted vertices.
+//   - error: an error if the graph is undirected or contains a cycle.
+//
+// Errors:
+//   - *ErrUndirected: if the graph is undirected.
+//   - *ErrCycle: if the graph contains a cycle, including self-loops.
+func (g *Graph[T]) TopologicalSort() ([]T, error) {
+	if g.isUndirected {
+		return nil, NewErrUndirected()
+	}
+
+	n := len(g.vertices)
+
+	inDegree := make([]int, n)
+
+	for _, row := range g.edges {
+		for j, w := range row {
+			if w != nil {
+				inDegree[j]++
+			}
+		}
+	}
+
+	done := make([]bool, n)
+	sorted := make([]T, 0, n)
+
+	for len(sorted) < n {
+		u := -1
+
+		for i, d := range inDegree {
+			if d == 0 && !done[i] {
+				u = i
+				break
+			}
+		}
+
+		if u == -1 {
+			break
+		}
+
+		done[u] = true
+		sorted = append(sorted, g.vertices[u])
+
+		for v, w := range g.edges[u] {
+			if w != nil {
+				inDegree[v]--
+			}
+		}
+	}
+
+	if len(sorted) != n {
+		return nil, NewErrCycle()
+	}
+
+	return sorted, nil
+}
+
+// HasCycle checks whether the graph contains a cycle.
+//
+// On a directed graph, any directed cycle (including a self-loop) counts. On
+// an undirected graph, an edge and its mirror do not form a cycle by
+// themselves; only self-loops and cycles through three or more vertices
+// count.
+//
+// Returns:
+//   - bool: true if the graph contains a cycle, otherwise false.
+func (g *Graph[T]) HasCycle() bool {
+	n := len(g.vertices)
+
+	visited := make([]bool, n)
+
+	if g.isUndirected {
+		for i := range g.vertices {
+			if !visited[i] && g.hasUndirectedCycle(i, -1, visited) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	onStack := make([]bool, n)
+
+	for i := range g.vertices {
+		if !visited[i] && g.hasDirectedCycle(i, visited, onStack) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasDirectedCycle performs a DFS from the given index looking for an edge
+// back to a vertex on the recursion stack.
+//
+// Parameters:
+//   - u: the index of the current vertex.
+//   - visited: the vertices visited so far.
+//   - onStack: the vertices on the recursion stack.
+//
+// Returns:
+//   - bool: true if a cycle was found, otherwise false.
+func (g *Graph[T]) hasDirectedCycle(u int, visited, onStack []bool) bool {
+	visited[u] = true
+	onStack[u] = true
+
+	for v, w := range g.edges[u] {
+		if w == nil {
+			continue
+		}
+
+		if onStack[v] {
+			return true
+		}
+
+		if !visited[v] && g.hasDirectedCycle(v, visited, onStack) {
+			return true
+		}
+	}
+
+	onStack[u] = false
+
+	return false
+}
+
+// hasUndirectedCycle performs a DFS from the given index looking for an edge
+// to an already visited vertex other than the parent.
+//
+// Parameters:
+//   - u: the index of the current vertex.
+//   - parent: the index of the vertex u was reached from, or -1.
+//   - visited: the vertices visited so far.
+//
+// Returns:
+//   - bool: true if a cycle was found, otherwise false.
+func (g *Graph[T]) hasUndirectedCycle(u, parent int, visited []bool) bool {
+	visited[u] = true
+
+	for v, w := range g.edges[u] {
+		if w == nil || v == parent {
+			continue
+		}
+
+		if visited[v] {
+			return true
+		}
+
+		if g.hasUndirectedCycle(v, u, visited) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ConnectedComponents partitions the vertices of the graph into connected
+// components. Edges are treated as undirected and vertices without edges form
+// their own component.
+//
+// Returns:
+//   - [][]T: the components, each in the order its vertices were reached.
+func (g *Graph[T]) ConnectedComponents() [][]T {
+	visited := make([]bool, len(g.vertices))
+
+	var components [][]T
+
+	for i := range g.vertices {
+		if visited[i] {
+			continue
+		}
+
+		visited[i] = true
+
+		queue := []int{i}
+		var component []T
+
+		for len(queue) > 0 {
+			u := queue[0]
+			queue = queue[1:]
+
+			component = append(component, g.vertices[u])
+
+			for v := range g.vertices {
+				if visited[v] || (g.edges[u][v] == nil && g.edges[v][u] == nil) {
+					continue
+				}
+
+				visited[v] = true
+				queue = append(queue, v)
+			}
+		}
+
+		components = append(components, component)
+	}
+
+	return components
+}
+
+// BFS traverses the graph in breadth-first order starting from the given
+// vertex, following directed edges.
+//
+// Parameters:
+//   - start: the vertex to start from.
+//
+// Returns:
+//   - []T: the reachable vertices, in the order they were visited.
+//   - error: an error if the start vertex is not in the graph.
+//
+// Errors:
+//   - *uc.ErrInvalidParameter: if the start vertex is not in the graph.
+func (g *Graph[T]) BFS(start T) ([]T, error) {
+	return g.BFSCtx(context.Background(), start)
+}
+
+// BFSCtx is like BFS but stops as soon as the given context is done.
+//
+// Parameters:
+//   - ctx: the context of the traversal.
+//   - start: the vertex to start from.
+//
+// Returns:
+//   - []T: the reachable vertices, in the order they were visited.
+//   - error: an error if the start vertex is not in the graph or if the
+//     context is done before the traversal ends.
+//
+// Errors:
+//   - *uc.ErrInvalidParameter: if the start vertex is not in the graph.
+//   - ctx.Err(): if the context is done.
+func (g *Graph[T]) BFSCtx(ctx context.Context, start T) ([]T, error) {
+	i := g.IndexOf(start)
+	if i == -1 {
+		return nil, uc.NewErrInvalidParameter("start", NewErrVertexNotFound())
+	}
+
+	order, err := g.bfsFrom(ctx, i)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.toVertices(order), nil
+}
+
+// DFS traverses the graph in depth-first order starting from the given
+// vertex, following directed edges.
+//
+// Parameters:
+//   - start: the vertex to start from.
+//
+// Returns:
+//   - []T: the reachable vertices, in the order they were visited.
+//   - error: an error if the start vertex is not in the graph.
+//
+// Errors:
+//   - *uc.ErrInvalidParameter: if the start vertex is not in the graph.
+func (g *Graph[T]) DFS(start T) ([]T, error) {
+	return g.DFSCtx(context.Background(), start)
+}
+
+// DFSCtx is like DFS but stops as soon as the given context is done.
+//
+// Parameters:
+//   - ctx: the context of the traversal.
+//   - start: the vertex to start from.
+//
+// Returns:
+//   - []T: the reachable vertices, in the order they were visited.
+//   - error: an error if the start vertex is not in the graph or if the
+//     context is done before the traversal ends.
+//
+// Errors:
+//   - *uc.ErrInvalidParameter: if the start vertex is not in the graph.
+//   - ctx.Err(): if the context is done.
+func (g *Graph[T]) DFSCtx(ctx context.Context, start T) ([]T, error) {
+	i := g.IndexOf(start)
+	if i == -1 {
+		return nil, uc.NewErrInvalidParameter("start", NewErrVertexNotFound())
+	}
+
+	order, err := g.dfsFrom(ctx, i)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.toVertices(order), nil
+}
+
+// bfsFrom returns the indices of the vertices reachable from the given index
+// in breadth-first order.
+//
+// Parameters:
+//   - ctx: the context of the traversal.
+//   - start: the index of the vertex to start from.
+//
+// Returns:
+//   - []int: the indices of the visited vertices.
+//   - error: ctx.Err() if the context is done before the traversal ends.
+func (g *Graph[T]) bfsFrom(ctx context.Context, start int) ([]int, error) {
+	visited := make([]bool, len(g.vertices))
+	visited[start] = true
+
+	order := []int{start}
+
+	for k := 0; k < len(order); k++ {
+		err := ctx.Err()
+		if err != nil {
+			return nil, err
+		}
+
+		for v, w := range g.edges[order[k]] {
+			if w == nil || visited[v] {
+				continue
+			}
+
+			visited[v] = true
+			order = append(order, v)
+		}
+	}
+
+	return order, nil
+}
+
+// dfsFrom returns the indices of the vertices reachable from the given index
+// in depth-first order.
+//
+// Parameters:
+//   - ctx: the context of the traversal.
+//   - start: the index of the vertex to start from.
+//
+// Returns:
+//   - []int: the indices of the visited vertices.
+//   - error: ctx.Err() if the context is done before the traversal ends.
+func (g *Graph[T]) dfsFrom(ctx context.Context, start int) ([]int, error) {
+	visited := make([]bool, len(g.vertices))
+
+	var order []int
+
+	stack := []int{start}
+
+	for len(stack) > 0 {
+		err := ctx.Err()
+		if err != nil {
+			return nil, err
+		}
+
+		u := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if visited[u] {
+			continue
+		}
+
+		visited[u] = true
+		order = append(order, u)
+
+		row := g.edges[u]
+
+		for v := len(row) - 1; v >= 0; v-- {
+			if row[v] != nil && !visited[v] {
+				stack = append(stack, v)
+			}
+		}
+	}
+
+	return order, nil
+}
+
+// toVertices maps the given indices to their vertices.
+//
+// Parameters:
+//   - indices: the indices of the vertices.
+//
+// Returns:
+//   - []T: the vertices.
+func (g *Graph[T]) toVertices(indices []int) []T {
+	vertices := make([]T, 0, len(indices))
+
+	for _, i := range indices {
+		vertices = append(vertices, g.vertices[i])
+	}
+
+	return vertices
+}
+
+// CanReach checks whether the destination vertex can be reached from the
+// source vertex by following directed edges. A vertex can always reach
+// itself.
+//
+// Parameters:
+//   - from: the source vertex.
+//   - to: the destination vertex.
+//
+// Returns:
+//   - bool: true if to is reachable from from, false otherwise or if either
+//     vertex is not in the graph.
+func (g *Graph[T]) CanReach(from, to T) bool {
+	i := g.IndexOf(from)
+	j := g.IndexOf(to)
+
+	if i == -1 || j == -1 {
+		return false
+	}
+
+	order, _ := g.bfsFrom(context.Background(), i)
+
+	for _, k := range order {
+		if k == j {
+			return true
+		}
+	}
+
+	return false
+}
+
+// tarjanState holds the bookkeeping of Tarjan's algorithm.
+type tarjanState struct {
+	// counter is the next index to assign.
+	counter int
+
+	// index is the discovery index of each vertex, or -1 if not visited.
+	index []int
+
+	// lowLink is the smallest index reachable from each vertex.
+	lowLink []int
+
+	// onStack is true for the vertices on the stack.
+	onStack []bool
+
+	// stack is the stack of visited vertices.
+	stack []int
+
+	// components are the components found so far.
+	components [][]int
+}
+
+// StronglyConnectedComponents partitions the vertices of the graph into
+// strongly connected components using Tarjan's algorithm. Each component is
+// a maximal set of vertices that can all reach each other; a vertex that is
+// not part of any cycle forms its own component.
+//
+// Returns:
+//   - [][]T: the components, in reverse topological order.
+func (g *Graph[T]) StronglyConnectedComponents() [][]T {
+	n := len(g.vertices)
+
+	state := &tarjanState{
+		index:   make([]int, n),
+		lowLink: make([]int, n),
+		onStack: make([]bool, n),
+	}
+
+	for i := range state.index {
+		state.index[i] = -1
+	}
+
+	for i := range g.vertices {
+		if state.index[i] == -1 {
+			g.strongConnect(i, state)
+		}
+	}
+
+	components := make([][]T, 0, len(state.components))
+
+	for _, indices := range state.components {
+		components = append(components, g.toVertices(indices))
+	}
+
+	return components
+}
+
+// strongConnect visits the given vertex as part of Tarjan's algorithm.
+//
+// Parameters:
+//   - u: the index of the vertex to visit.
+//   - state: the state of the algorithm.
+func (g *Graph[T]) strongConnect(u int, state *tarjanState) {
+	state.index[u] = state.counter
+	state.lowLink[u] = state.counter
+	state.counter++
+
+	state.stack = append(state.stack, u)
+	state.onStack[u] = true
+
+	for v, w := range g.edges[u] {
+		if w == nil {
+			continue
+		}
+
+		if state.index[v] == -1 {
+			g.strongConnect(v, state)
+			state.lowLink[u] = min(state.lowLink[u], state.lowLink[v])
+		} else if state.onStack[v] {
+			state.lowLink[u] = min(state.lowLink[u], state.index[v])
+		}
+	}
+
+	if state.lowLink[u] != state.index[u] {
+		return
+	}
+
+	var component []int
+
+	for {
+		top := state.stack[len(state.stack)-1]
+		state.stack = state.stack[:len(state.stack)-1]
+		state.onStack[top] = false
+
+		component = append(component, top)
+
+		if top == u {
+			break
+		}
+	}
+
+	state.components = append(state.components, component)
+}
+
+// IsBipartite checks whether the vertices of the graph can be split into two
+// sets such that every edge goes from one set to the other. Edges are treated
+// as undirected, so a self-loop makes the graph not bipartite.
+//
+// Returns:
+//   - bool: true if the graph is bipartite, otherwise false.
+//   - [2][]T: the two sets of vertices, or two empty sets if the graph is not
+//     bipartite.
+func (g *Graph[T]) IsBipartite() (bool, [2][]T) {
+	color := make([]int, len(g.vertices))
+
+	for i := range color {
+		color[i] = -1
+	}
+
+	for i := range g.vertices {
+		if color[i] != -1 {
+			continue
+		}
+
+		color[i] = 0
+
+		queue := []int{i}
+
+		for len(queue) > 0 {
+			u := queue[0]
+			queue = queue[1:]
+
+			for v := range g.vertices {
+				if g.edges[u][v] == nil && g.edges[v][u] == nil {
+					continue
+				}
+
+				if color[v] == -1 {
+					color[v] = 1 - color[u]
+					queue = append(queue, v)
+				} else if color[v] == color[u] {
+					return false, [2][]T{}
+				}
+			}
+		}
+	}
+
+	var sets [2][]T
+
+	for i, c := range color {
+		sets[c] = append(sets[c], g.vertices[i])
+	}
+
+	return true, sets
+}
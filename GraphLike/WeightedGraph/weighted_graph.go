@@ -1,6 +1,9 @@
 package WeightedGraph
 
 import (
+	"cmp"
+	"slices"
+
 	uc "github.com/PlayerR9/lib_units/common"
 	tn "github.com/PlayerR9/tree"
 	tr "github.com/PlayerR9/tree/tree"
@@ -17,6 +20,40 @@ import (
 //   - bool: true if the edge exists, otherwise false.
 type WeightFunc[T uc.Objecter] func(from, to T) (float64, bool)
 
+// CombineWeights creates a weight function whose weight is the sum of the
+// weights reported by the given functions. An edge exists if at least one of
+// the functions reports it; nil functions are ignored.
+//
+// Parameters:
+//   - funcs: the weight functions to combine.
+//
+// Returns:
+//   - WeightFunc: the combined weight function.
+func CombineWeights[T uc.Objecter](funcs ...WeightFunc[T]) WeightFunc[T] {
+	filtered := make([]WeightFunc[T], 0, len(funcs))
+
+	for _, f := range funcs {
+		if f != nil {
+			filtered = append(filtered, f)
+		}
+	}
+
+	return func(from, to T) (float64, bool) {
+		var total float64
+		var found bool
+
+		for _, f := range filtered {
+			w, ok := f(from, to)
+			if ok {
+				total += w
+				found = true
+			}
+		}
+
+		return total, found
+	}
+}
+
 // Graph represents a graph.
 type Graph[T uc.Objecter] struct {
 	// vertices in the graph.
@@ -24,12 +61,25 @@ type Graph[T uc.Objecter] struct {
 
 	// edges in the graph.
 	edges [][]*float64
+
+	// isUndirected is true if every edge is mirrored in both directions.
+	isUndirected bool
+
+	// keyFunc is the function that gives the key of a vertex, or nil if
+	// vertices are looked up with a linear scan.
+	keyFunc func(T) string
+
+	// indices maps the key of each vertex to its index. Only used if keyFunc
+	// is not nil.
+	indices map[string]int
 }
 
-// NewGraph creates a new graph with the given vertices.
+// NewGraph creates a new graph with the given vertices. The vertices slice
+// is copied, so later changes to the graph do not affect it.
 //
 // Parameters:
 //   - vertices: vertices in the graph.
+//   - f: the weight function.
 //
 // Returns:
 //   - *WeightedGraph: the new graph.
@@ -42,14 +92,19 @@ func NewGraph[T uc.Objecter](vertices []T, f WeightFunc[T]) *Graph[T] {
 	}
 
 	g := &Graph[T]{
-		vertices: vertices,
+		vertices: make([]T, len(vertices)),
 		edges:    make([][]*float64, 0, len(vertices)),
 	}
 
+	copy(g.vertices, vertices)
+
 	for _, from := range vertices {
 		edge := make([]*float64, 0, len(vertices))
 
 		for _, to := range vertices {
+			// w is declared anew on every iteration, so each edge points to
+			// its own float64. Hoisting it out of the loop would make every
+			// edge share the same weight.
 			w, ok := f(from, to)
 			if !ok {
 				edge = append(edge, nil)
@@ -64,6 +119,118 @@ func NewGraph[T uc.Objecter](vertices []T, f WeightFunc[T]) *Graph[T] {
 	return g
 }
 
+// NewUndirectedGraph creates a new undirected graph with the given vertices.
+//
+// The weight of the edge between two vertices is the first one reported by
+// f(from, to) or, if that edge does not exist, by f(to, from), where from
+// comes before to in the vertices slice. The same weight is then used in both
+// directions. As with NewGraph, the vertices slice is copied.
+//
+// Parameters:
+//   - vertices: vertices in the graph.
+//   - f: the weight function.
+//
+// Returns:
+//   - *Graph: the new graph.
+func NewUndirectedGraph[T uc.Objecter](vertices []T, f WeightFunc[T]) *Graph[T] {
+	if len(vertices) == 0 {
+		return &Graph[T]{
+			vertices:     make([]T, 0),
+			edges:        make([][]*float64, 0),
+			isUndirected: true,
+		}
+	}
+
+	g := &Graph[T]{
+		vertices:     make([]T, len(vertices)),
+		edges:        makeMatrix(len(vertices)),
+		isUndirected: true,
+	}
+
+	copy(g.vertices, vertices)
+
+	for i, from := range vertices {
+		for j := i; j < len(vertices); j++ {
+			w, ok := undirectedWeight(f, from, vertices[j])
+			if !ok {
+				continue
+			}
+
+			// The two directions get their own copy so that they never
+			// alias each other.
+			w1, w2 := w, w
+
+			g.edges[i][j] = &w1
+			g.edges[j][i] = &w2
+		}
+	}
+
+	return g
+}
+
+// undirectedWeight returns the weight of the edge between the given vertices,
+// trying both directions.
+//
+// Parameters:
+//   - f: the weight function.
+//   - a: the first vertex.
+//   - b: the second vertex.
+//
+// Returns:
+//   - float64: the weight of the edge.
+//   - bool: true if the edge exists in either direction, otherwise false.
+func undirectedWeight[T uc.Objecter](f WeightFunc[T], a, b T) (float64, bool) {
+	w, ok := f(a, b)
+	if ok {
+		return w, true
+	}
+
+	return f(b, a)
+}
+
+// IsUndirected checks whether the graph is undirected.
+//
+// Returns:
+//   - bool: true if the graph was created with NewUndirectedGraph, otherwise false.
+func (g *Graph[T]) IsUndirected() bool {
+	return g.isUndirected
+}
+
+// SetKeyFunc sets the function that gives the key of a vertex. When set,
+// IndexOf looks vertices up in an internal map instead of scanning them,
+// which turns it from O(n) into O(1).
+//
+// Two vertices must have the same key if and only if they are equal according
+// to their Equals method. If several vertices share a key, the first one is
+// used, as with the linear scan.
+//
+// Parameters:
+//   - f: the key function. If nil, the linear scan is used.
+func (g *Graph[T]) SetKeyFunc(f func(T) string) {
+	g.keyFunc = f
+	g.reindex()
+}
+
+// reindex rebuilds the map from keys to indices. Does nothing if no key
+// function is set.
+func (g *Graph[T]) reindex() {
+	if g.keyFunc == nil {
+		g.indices = nil
+		return
+	}
+
+	g.indices = make(map[string]int, len(g.vertices))
+
+	for i, v := range g.vertices {
+		key := g.keyFunc(v)
+
+		_, ok := g.indices[key]
+		if !ok {
+			g.indices[key] = i
+		}
+	}
+}
+
 // IndexOf returns the index of the given element in the graph.
 //
 // Parameters:
@@ -72,6 +239,15 @@ func NewGraph[T uc.Objecter](vertices []T, f WeightFunc[T]) *Graph[T] {
 // Returns:
 //   - int: the index of the element, or -1 if not found.
 func (g *Graph[T]) IndexOf(elem T) int {
+	if g.keyFunc != nil {
+		i, ok := g.indices[g.keyFunc(elem)]
+		if !ok {
+			return -1
+		}
+
+		return i
+	}
+
 	for i, x := range g.vertices {
 		if x.Equals(elem) {
 			return i
@@ -105,6 +281,72 @@ func (g *Graph[T]) AdjacentOf(from T) []T {
 	return adj
 }
 
+// AdjacentWithWeights returns the adjacent vertices of the given vertex along
+// with the weights of the edges leading to them.
+//
+// Parameters:
+//   - from: the source vertex.
+//
+// Returns:
+//   - []T: the adjacent vertices.
+//   - []float64: the weight of the edge to each adjacent vertex.
+func (g *Graph[T]) AdjacentWithWeights(from T) ([]T, []float64) {
+	index := g.IndexOf(from)
+	if index == -1 {
+		return nil, nil
+	}
+
+	adj := make([]T, 0)
+	weights := make([]float64, 0)
+
+	for j, distance := range g.edges[index] {
+		if distance != nil {
+			adj = append(adj, g.vertices[j])
+			weights = append(weights, *distance)
+		}
+	}
+
+	return adj, weights
+}
+
+// AdjacentSorted returns the adjacent vertices of the given vertex sorted by
+// the weight of the edge leading to them. Ties are broken by the order of the
+// vertices in the graph.
+//
+// Parameters:
+//   - from: the source vertex.
+//   - ascending: true to sort from the lightest edge to the heaviest one,
+//     false for the opposite.
+//
+// Returns:
+//   - []T: the sorted adjacent vertices.
+func (g *Graph[T]) AdjacentSorted(from T, ascending bool) []T {
+	index := g.IndexOf(from)
+	if index == -1 {
+		return nil
+	}
+
+	row := g.edges[index]
+
+	var indices []int
+
+	for j, distance := range row {
+		if distance != nil {
+			indices = append(indices, j)
+		}
+	}
+
+	slices.SortStableFunc(indices, func(a, b int) int {
+		if ascending {
+			return cmp.Compare(*row[a], *row[b])
+		}
+
+		return cmp.Compare(*row[b], *row[a])
+	})
+
+	return g.toVertices(indices)
+}
+
 // MakeTree creates a tree of the graph with the given root.
 //
 // Parameters:
@@ -152,7 +394,42 @@ func (g *Graph[T]) GetEdge(from, to T) (float64, bool) {
 	i := g.IndexOf(from)
 	j := g.IndexOf(to)
 
-	if i == -1 || j == -1 {
+	return g.GetEdgeByIndex(i, j)
+}
+
+// HasEdge checks whether there is an edge between the given vertices.
+//
+// Parameters:
+//   - from: the source vertex.
+//   - to: the destination vertex.
+//
+// Returns:
+//   - bool: true if the edge exists, false otherwise or if the graph is nil.
+func (g *Graph[T]) HasEdge(from, to T) bool {
+	if g == nil {
+		return false
+	}
+
+	_, ok := g.GetEdge(from, to)
+	return ok
+}
+
+// GetEdgeByIndex returns the weight of the edge between the vertices at the
+// given indices. This avoids looking the vertices up when their indices are
+// already known.
+//
+// Parameters:
+//   - i: the index of the source vertex.
+//   - j: the index of the destination vertex.
+//
+// Returns:
+//   - float64: the weight of the edge.
+//   - bool: true if the edge exists, false otherwise or if either index is out
+//     of bounds.
+func (g *Graph[T]) GetEdgeByIndex(i, j int) (float64, bool) {
+	n := len(g.vertices)
+
+	if i < 0 || i >= n || j < 0 || j >= n {
 		return 0, false
 	}
 
@@ -163,3 +440,393 @@ func (g *Graph[T]) GetEdge(from, to T) (float64, bool) {
 
 	return *w, true
 }
+
+// AddVertex adds the given vertex to the graph and computes its edges with
+// the given weight function. Does nothing if the vertex is already in the
+// graph.
+//
+// Parameters:
+//   - v: the vertex to add.
+//   - f: the weight function. If nil, the vertex is added without edges.
+func (g *Graph[T]) AddVertex(v T, f WeightFunc[T]) {
+	if g.IndexOf(v) != -1 {
+		return
+	}
+
+	g.vertices = append(g.vertices, v)
+
+	n := len(g.vertices)
+
+	if g.keyFunc != nil {
+		g.indices[g.keyFunc(v)] = n - 1
+	}
+
+	row := make([]*float64, n)
+
+	for i, from := range g.vertices[:n-1] {
+		var to *float64
+
+		if f != nil && g.isUndirected {
+			w, ok := undirectedWeight(f, from, v)
+			if ok {
+				w1, w2 := w, w
+
+				to = &w1
+				row[i] = &w2
+			}
+		} else if f != nil {
+			w1, ok := f(from, v)
+			if ok {
+				to = &w1
+			}
+
+			w2, ok := f(v, from)
+			if ok {
+				row[i] = &w2
+			}
+		}
+
+		g.edges[i] = append(g.edges[i], to)
+	}
+
+	if f != nil {
+		w, ok := f(v, v)
+		if ok {
+			row[n-1] = &w
+		}
+	}
+
+	g.edges = append(g.edges, row)
+}
+
+// RemoveVertex removes the given vertex and all of its edges from the graph.
+//
+// Parameters:
+//   - v: the vertex to remove.
+//
+// Returns:
+//   - bool: true if the vertex was removed, false if it is not in the graph.
+func (g *Graph[T]) RemoveVertex(v T) bool {
+	index := g.IndexOf(v)
+	if index == -1 {
+		return false
+	}
+
+	g.vertices = slices.Delete(g.vertices, index, index+1)
+	g.edges = slices.Delete(g.edges, index, index+1)
+
+	for i, row := range g.edges {
+		g.edges[i] = slices.Delete(row, index, index+1)
+	}
+
+	g.reindex()
+
+	return true
+}
+
+// SetEdge sets the weight of the edge between the given vertices. If the
+// edge already exists, its weight is overwritten. On an undirected graph, the
+// opposite edge is set as well.
+//
+// Parameters:
+//   - from: the source vertex.
+//   - to: the destination vertex.
+//   - weight: the weight of the edge.
+//
+// Returns:
+//   - bool: true if the edge was set, false if either vertex is not in the graph.
+func (g *Graph[T]) SetEdge(from, to T, weight float64) bool {
+	i := g.IndexOf(from)
+	j := g.IndexOf(to)
+
+	if i == -1 || j == -1 {
+		return false
+	}
+
+	g.setWeight(i, j, weight)
+
+	if g.isUndirected && i != j {
+		g.setWeight(j, i, weight)
+	}
+
+	return true
+}
+
+// RemoveEdge removes the edge between the given vertices. On an undirected
+// graph, the opposite edge is removed as well.
+//
+// Parameters:
+//   - from: the source vertex.
+//   - to: the destination vertex.
+//
+// Returns:
+//   - bool: true if the edge was removed, false if either vertex is not in the
+//     graph or if there is no such edge.
+func (g *Graph[T]) RemoveEdge(from, to T) bool {
+	i := g.IndexOf(from)
+	j := g.IndexOf(to)
+
+	if i == -1 || j == -1 || g.edges[i][j] == nil {
+		return false
+	}
+
+	g.edges[i][j] = nil
+
+	if g.isUndirected {
+		g.edges[j][i] = nil
+	}
+
+	return true
+}
+
+// setWeight sets the weight of the edge at the given indices, overwriting the
+// stored value if the edge already exists.
+//
+// Parameters:
+//   - i: the index of the source vertex.
+//   - j: the index of the destination vertex.
+//   - weight: the weight of the edge.
+func (g *Graph[T]) setWeight(i, j int, weight float64) {
+	if g.edges[i][j] != nil {
+		*g.edges[i][j] = weight
+	} else {
+		g.edges[i][j] = &weight
+	}
+}
+
+// makeMatrix creates an edge matrix of the given size without any edges.
+//
+// Parameters:
+//   - n: the number of vertices.
+//
+// Returns:
+//   - [][]*float64: the new matrix.
+func makeMatrix(n int) [][]*float64 {
+	edges := make([][]*float64, n)
+
+	for i := range edges {
+		edges[i] = make([]*float64, n)
+	}
+
+	return edges
+}
+
+// Transpose creates a new graph with the same vertices and every edge
+// reversed. The original graph is not modified.
+//
+// Returns:
+//   - *Graph: the transposed graph.
+func (g *Graph[T]) Transpose() *Graph[T] {
+	n := len(g.vertices)
+
+	t := &Graph[T]{
+		vertices:     make([]T, n),
+		edges:        makeMatrix(n),
+		isUndirected: g.isUndirected,
+	}
+
+	copy(t.vertices, g.vertices)
+
+	for i, row := range g.edges {
+		for j, w := range row {
+			if w != nil {
+				weight := *w
+				t.edges[j][i] = &weight
+			}
+		}
+	}
+
+	t.SetKeyFunc(g.keyFunc)
+
+	return t
+}
+
+// Subgraph creates a new graph with only the vertices that satisfy the given
+// predicate and the edges among them. The original graph is not modified.
+//
+// Parameters:
+//   - keep: the predicate. If nil, every vertex is kept.
+//
+// Returns:
+//   - *Graph: the subgraph.
+func (g *Graph[T]) Subgraph(keep func(T) bool) *Graph[T] {
+	var indices []int
+
+	for i, v := range g.vertices {
+		if keep == nil || keep(v) {
+			indices = append(indices, i)
+		}
+	}
+
+	sub := &Graph[T]{
+		vertices:     g.toVertices(indices),
+		edges:        makeMatrix(len(indices)),
+		isUndirected: g.isUndirected,
+	}
+
+	for si, i := range indices {
+		for sj, j := range indices {
+			w := g.edges[i][j]
+			if w != nil {
+				weight := *w
+				sub.edges[si][sj] = &weight
+			}
+		}
+	}
+
+	sub.SetKeyFunc(g.keyFunc)
+
+	return sub
+}
+
+// Equals checks whether the graph is equal to the other graph. Two graphs are
+// equal if they are both directed or both undirected, have the same vertices
+// according to their Equals method, regardless of their order, and have the
+// same edges with the same weights. Duplicate vertices are matched in order
+// with the unused equal vertices of the other graph.
+//
+// Parameters:
+//   - other: the other graph.
+//
+// Returns:
+//   - bool: true if the graphs are equal, otherwise false.
+func (g *Graph[T]) Equals(other *Graph[T]) bool {
+	if other == nil {
+		return false
+	}
+
+	if g.isUndirected != other.isUndirected || len(g.vertices) != len(other.vertices) {
+		return false
+	}
+
+	mapping := make([]int, len(g.vertices))
+	seen := make([]bool, len(other.vertices))
+
+	for i, v := range g.vertices {
+		j := other.IndexOf(v)
+
+		if j != -1 && seen[j] {
+			// v is a duplicate; match it with an unused equal vertex instead.
+			j = -1
+
+			for k, ov := range other.vertices {
+				if !seen[k] && ov.Equals(v) {
+					j = k
+					break
+				}
+			}
+		}
+
+		if j == -1 {
+			return false
+		}
+
+		mapping[i] = j
+		seen[j] = true
+	}
+
+	for i, row := range g.edges {
+		for j, w := range row {
+			if !sameWeight(w, other.edges[mapping[i]][mapping[j]]) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Clone creates a deep copy of the graph.
+//
+// Edges are stored as *float64, so copying the Graph struct or its edge
+// matrix shares the weights: changing an edge through SetEdge on the copy
+// would change it on the original too. Clone instead allocates a new weight
+// for every edge and copies every vertex with its Copy method.
+//
+// Returns:
+//   - *Graph: the copy of the graph.
+func (g *Graph[T]) Clone() *Graph[T] {
+	clone := g.Subgraph(nil)
+
+	for i, v := range clone.vertices {
+		clone.vertices[i] = v.Copy().(T)
+	}
+
+	return clone
+}
+
+// RemoveSelfLoops removes every edge that goes from a vertex to itself.
+//
+// Returns:
+//   - int: the number of edges removed.
+func (g *Graph[T]) RemoveSelfLoops() int {
+	var count int
+
+	for i, row := range g.edges {
+		if row[i] != nil {
+			row[i] = nil
+			count++
+		}
+	}
+
+	return count
+}
+
+// Merge creates a new graph with the vertices and edges of both graphs. Equal
+// vertices are merged together and, when both graphs have the same edge, the
+// weight from g is kept. The result is undirected only if both graphs are.
+// Neither graph is modified.
+//
+// Parameters:
+//   - other: the graph to merge with.
+//
+// Returns:
+//   - *Graph: the merged graph.
+func (g *Graph[T]) Merge(other *Graph[T]) *Graph[T] {
+	merged := g.Subgraph(nil)
+
+	if other == nil {
+		return merged
+	}
+
+	merged.isUndirected = g.isUndirected && other.isUndirected
+
+	for _, v := range other.vertices {
+		merged.AddVertex(v, nil)
+	}
+
+	mapping := make([]int, len(other.vertices))
+
+	for i, v := range other.vertices {
+		mapping[i] = merged.IndexOf(v)
+	}
+
+	for i, row := range other.edges {
+		for j, w := range row {
+			mi, mj := mapping[i], mapping[j]
+
+			if w != nil && merged.edges[mi][mj] == nil {
+				weight := *w
+				merged.edges[mi][mj] = &weight
+			}
+		}
+	}
+
+	return merged
+}
+
+// sameWeight checks whether two edges are both missing or have the same weight.
+//
+// Parameters:
+//   - a: the first edge.
+//   - b: the second edge.
+//
+// Returns:
+//   - bool: true if the edges are the same, false otherwise.
+func sameWeight(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
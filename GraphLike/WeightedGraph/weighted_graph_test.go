@@ -0,0 +1,709 @@
+package WeightedGraph
+
+import (
+	"math"
+	"slices"
+	"strconv"
+	"testing"
+
+	uc "github.com/PlayerR9/lib_units/common"
+)
+
+// testVertex is a vertex used for testing.
+type testVertex int
+
+// String implements the fmt.Stringer interface.
+func (v testVertex) String() string {
+	return strconv.Itoa(int(v))
+}
+
+// Copy implements the uc.Copier interface.
+func (v testVertex) Copy() uc.Copier {
+	return v
+}
+
+// Equals implements the uc.Equaler interface.
+func (v testVertex) Equals(other uc.Equaler) bool {
+	otherV, ok := other.(testVertex)
+	return ok && otherV == v
+}
+
+// newTestVertices creates the vertices 0 to n-1.
+func newTestVertices(n int) []testVertex {
+	vertices := make([]testVertex, 0, n)
+
+	for i := 0; i < n; i++ {
+		vertices = append(vertices, testVertex(i))
+	}
+
+	return vertices
+}
+
+// completeWeights is a weight function that connects every pair of vertices,
+// self-loops included, with a weight of 1.
+func completeWeights(from, to testVertex) (float64, bool) {
+	return 1, true
+}
+
+// noWeights is a weight function that connects no vertices.
+func noWeights(from, to testVertex) (float64, bool) {
+	return 0, false
+}
+
+// edgeWeights creates a weight function from the given edges, keyed by the
+// pair of vertices they connect.
+func edgeWeights(edges map[[2]testVertex]float64) WeightFunc[testVertex] {
+	return func(from, to testVertex) (float64, bool) {
+		w, ok := edges[[2]testVertex{from, to}]
+		return w, ok
+	}
+}
+
+func TestNoSharedWeights(t *testing.T) {
+	tests := []struct {
+		name  string
+		graph *Graph[testVertex]
+	}{
+		{"directed", NewGraph(newTestVertices(4), completeWeights)},
+		{"undirected", NewUndirectedGraph(newTestVertices(4), completeWeights)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seen := make(map[*float64]bool)
+
+			for i, row := range tt.graph.edges {
+				for j, w := range row {
+					if w == nil {
+						t.Fatalf("expected an edge from %d to %d", i, j)
+					}
+
+					if seen[w] {
+						t.Fatalf("edge from %d to %d shares its weight with another edge", i, j)
+					}
+
+					seen[w] = true
+				}
+			}
+		})
+	}
+}
+
+func TestSetEdgeLeavesOtherEdges(t *testing.T) {
+	tests := []struct {
+		name     string
+		graph    *Graph[testVertex]
+		mirrored bool
+	}{
+		{"directed", NewGraph(newTestVertices(4), completeWeights), false},
+		{"undirected", NewUndirectedGraph(newTestVertices(4), completeWeights), true},
+	}
+
+	const from, to = 1, 2
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok := tt.graph.SetEdge(from, to, 42)
+			if !ok {
+				t.Fatalf("expected SetEdge to succeed")
+			}
+
+			for i, row := range tt.graph.edges {
+				for j, w := range row {
+					changed := (i == from && j == to) || (tt.mirrored && i == to && j == from)
+
+					want := 1.0
+					if changed {
+						want = 42
+					}
+
+					if *w != want {
+						t.Errorf("edge from %d to %d: got %v, want %v", i, j, *w, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestNewGraphCopiesVertices(t *testing.T) {
+	vertices := newTestVertices(3)
+
+	g := NewGraph(vertices, completeWeights)
+	g.RemoveVertex(0)
+
+	for i, v := range vertices {
+		if v != testVertex(i) {
+			t.Fatalf("vertex %d: got %v, want %v", i, v, i)
+		}
+	}
+}
+
+func TestUnmarshalJSONKeepsKeyFunc(t *testing.T) {
+	data, err := NewGraph(newTestVertices(3), completeWeights).MarshalJSON()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var g Graph[testVertex]
+	g.SetKeyFunc(testVertex.String)
+
+	err = g.UnmarshalJSON(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if g.indices == nil {
+		t.Fatalf("expected the key index to be rebuilt")
+	}
+
+	if i := g.IndexOf(2); i != 2 {
+		t.Fatalf("IndexOf(2): got %d, want 2", i)
+	}
+}
+
+func BenchmarkIndexOf(b *testing.B) {
+	const n = 5000
+
+	tests := []struct {
+		name  string
+		keyed bool
+	}{
+		{"linear", false},
+		{"keyed", true},
+	}
+
+	for _, tt := range tests {
+		b.Run(tt.name, func(b *testing.B) {
+			g := NewGraph(newTestVertices(n), noWeights)
+			if tt.keyed {
+				g.SetKeyFunc(testVertex.String)
+			}
+
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				g.IndexOf(testVertex(i % n))
+			}
+		})
+	}
+}
+
+func TestMergeDirectedWithUndirected(t *testing.T) {
+	directed := NewGraph(newTestVertices(1), noWeights)
+
+	undirected := NewUndirectedGraph([]testVertex{1, 2}, func(from, to testVertex) (float64, bool) {
+		return 3, from != to
+	})
+
+	merged := directed.Merge(undirected)
+
+	if merged.IsUndirected() {
+		t.Fatalf("expected the merged graph to be directed")
+	}
+
+	for _, e := range [][2]testVertex{{1, 2}, {2, 1}} {
+		w, ok := merged.GetEdge(e[0], e[1])
+		if !ok || w != 3 {
+			t.Errorf("edge from %v to %v: got (%v, %t), want (3, true)", e[0], e[1], w, ok)
+		}
+	}
+}
+
+func TestTopologicalSort(t *testing.T) {
+	tests := []struct {
+		name    string
+		graph   *Graph[testVertex]
+		want    []testVertex
+		wantErr error
+	}{
+		{
+			name: "ties broken by vertex order",
+			graph: NewGraph(newTestVertices(4), edgeWeights(map[[2]testVertex]float64{
+				{0, 1}: 1,
+				{3, 2}: 1,
+			})),
+			want: []testVertex{0, 1, 3, 2},
+		},
+		{
+			name: "cycle",
+			graph: NewGraph(newTestVertices(2), edgeWeights(map[[2]testVertex]float64{
+				{0, 1}: 1,
+				{1, 0}: 1,
+			})),
+			wantErr: &ErrCycle{},
+		},
+		{
+			name: "undirected",
+			graph: NewUndirectedGraph(newTestVertices(2), edgeWeights(map[[2]testVertex]float64{
+				{0, 1}: 1,
+			})),
+			wantErr: &ErrUndirected{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.graph.TopologicalSort()
+
+			if tt.wantErr != nil {
+				if err == nil || err.Error() != tt.wantErr.Error() {
+					t.Fatalf("got error %v, want %v", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewGraphFromJSONRoundTrip(t *testing.T) {
+	weights := edgeWeights(map[[2]testVertex]float64{
+		{0, 1}: 2,
+		{1, 2}: 0.5,
+		{2, 2}: -1,
+	})
+
+	tests := []struct {
+		name  string
+		graph *Graph[testVertex]
+	}{
+		{"directed", NewGraph(newTestVertices(4), weights)},
+		{"undirected", NewUndirectedGraph(newTestVertices(4), weights)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.graph.MarshalJSON()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got, err := NewGraphFromJSON(data, func() testVertex { return 0 })
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !got.Equals(tt.graph) {
+				t.Fatalf("decoded graph differs from the original: %s", data)
+			}
+		})
+	}
+}
+
+func TestNewGraphFromJSONAsymmetricUndirected(t *testing.T) {
+	data := []byte(`{"vertices":[0,1],"edges":[[null,null],[5,null]],"undirected":true}`)
+
+	_, err := NewGraphFromJSON(data, func() testVertex { return 0 })
+	if err == nil {
+		t.Fatalf("expected an error for an asymmetric undirected graph")
+	}
+}
+
+func TestBetweennessCentrality(t *testing.T) {
+	path := edgeWeights(map[[2]testVertex]float64{
+		{0, 1}: 1,
+		{1, 2}: 1,
+		{2, 3}: 1,
+	})
+
+	star := edgeWeights(map[[2]testVertex]float64{
+		{0, 1}: 1,
+		{0, 2}: 1,
+		{0, 3}: 1,
+		{0, 4}: 1,
+	})
+
+	tests := []struct {
+		name  string
+		graph *Graph[testVertex]
+		want  map[int]float64
+	}{
+		{
+			name:  "directed path",
+			graph: NewGraph(newTestVertices(3), path),
+			want:  map[int]float64{0: 0, 1: 0.5, 2: 0},
+		},
+		{
+			name:  "undirected path",
+			graph: NewUndirectedGraph(newTestVertices(4), path),
+			want:  map[int]float64{0: 0, 1: 2.0 / 3, 2: 2.0 / 3, 3: 0},
+		},
+		{
+			name:  "directed star",
+			graph: NewGraph(newTestVertices(5), star),
+			want:  map[int]float64{0: 0, 1: 0, 2: 0, 3: 0, 4: 0},
+		},
+		{
+			name:  "undirected star",
+			graph: NewUndirectedGraph(newTestVertices(5), star),
+			want:  map[int]float64{0: 1, 1: 0, 2: 0, 3: 0, 4: 0},
+		},
+		{
+			name:  "too few vertices",
+			graph: NewGraph(newTestVertices(2), completeWeights),
+			want:  map[int]float64{0: 0, 1: 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.graph.BetweennessCentrality()
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+
+			for i, want := range tt.want {
+				if math.Abs(got[i]-want) > 1e-9 {
+					t.Errorf("vertex %d: got %v, want %v", i, got[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestEqualsDuplicateVertices(t *testing.T) {
+	g := NewGraph([]testVertex{1, 1, 2}, completeWeights)
+
+	changed := g.Clone()
+	changed.SetEdge(1, 2, 42)
+
+	tests := []struct {
+		name  string
+		other *Graph[testVertex]
+		want  bool
+	}{
+		{"itself", g, true},
+		{"clone", g.Clone(), true},
+		{"different duplicates", NewGraph([]testVertex{1, 2, 2}, completeWeights), false},
+		{"different weight", changed, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := g.Equals(tt.other); got != tt.want {
+				t.Fatalf("got %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKShortestPaths(t *testing.T) {
+	yen := NewGraph(newTestVertices(6), edgeWeights(map[[2]testVertex]float64{
+		{0, 1}: 3,
+		{0, 2}: 2,
+		{1, 3}: 4,
+		{2, 1}: 1,
+		{2, 3}: 2,
+		{2, 4}: 3,
+		{3, 4}: 2,
+		{3, 5}: 1,
+		{4, 5}: 2,
+	}))
+
+	unreachable := NewGraph(newTestVertices(3), edgeWeights(map[[2]testVertex]float64{
+		{0, 1}: 1,
+	}))
+
+	tests := []struct {
+		name      string
+		graph     *Graph[testVertex]
+		from, to  testVertex
+		k         int
+		wantPaths [][]testVertex
+		wantCosts []float64
+		wantErr   bool
+	}{
+		{
+			name:  "classic example",
+			graph: yen,
+			from:  0,
+			to:    5,
+			k:     3,
+			wantPaths: [][]testVertex{
+				{0, 2, 3, 5},
+				{0, 2, 4, 5},
+				{0, 1, 3, 5},
+			},
+			wantCosts: []float64{5, 7, 8},
+		},
+		{
+			name:      "fewer than k paths",
+			graph:     yen,
+			from:      0,
+			to:        5,
+			k:         10,
+			wantCosts: []float64{5, 7, 8, 8, 8, 11, 11},
+		},
+		{
+			name:  "unreachable",
+			graph: unreachable,
+			from:  0,
+			to:    2,
+			k:     2,
+		},
+		{
+			name:    "k is zero",
+			graph:   yen,
+			from:    0,
+			to:      5,
+			k:       0,
+			wantErr: true,
+		},
+		{
+			name:    "k is negative",
+			graph:   yen,
+			from:    0,
+			to:      5,
+			k:       -1,
+			wantErr: true,
+		},
+		{
+			name:    "missing vertex",
+			graph:   yen,
+			from:    0,
+			to:      9,
+			k:       1,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paths, costs, err := tt.graph.KShortestPaths(tt.from, tt.to, tt.k)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantCosts == nil {
+				if paths != nil || costs != nil {
+					t.Fatalf("got (%v, %v), want (nil, nil)", paths, costs)
+				}
+
+				return
+			}
+
+			if !slices.Equal(costs, tt.wantCosts) {
+				t.Fatalf("costs: got %v, want %v", costs, tt.wantCosts)
+			}
+
+			if len(paths) != len(costs) {
+				t.Fatalf("got %d paths for %d costs", len(paths), len(costs))
+			}
+
+			if tt.wantPaths != nil && !slices.EqualFunc(paths, tt.wantPaths, slices.Equal) {
+				t.Fatalf("paths: got %v, want %v", paths, tt.wantPaths)
+			}
+
+			for i, path := range paths {
+				if path[0] != tt.from || path[len(path)-1] != tt.to {
+					t.Errorf("path %d: got %v, want a path from %v to %v", i, path, tt.from, tt.to)
+				}
+
+				for j, other := range paths[:i] {
+					if slices.Equal(path, other) {
+						t.Errorf("path %d is the same as path %d: %v", i, j, path)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestGraphBuilder(t *testing.T) {
+	tests := []struct {
+		name    string
+		keyFunc func(testVertex) string
+	}{
+		{"linear", nil},
+		{"keyed", testVertex.String},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b GraphBuilder[testVertex]
+			b.SetKeyFunc(tt.keyFunc)
+
+			b.AddEdge(0, 1, 1)
+			b.AddEdge(1, 2, 2)
+			b.AddEdge(0, 1, 5)
+			b.AddVertex(3)
+			b.AddVertex(1)
+
+			g := b.Build()
+
+			want := NewGraph(newTestVertices(4), edgeWeights(map[[2]testVertex]float64{
+				{0, 1}: 5,
+				{1, 2}: 2,
+			}))
+
+			if !g.Equals(want) {
+				t.Fatalf("got %v, want %v", g.EdgeList(), want.EdgeList())
+			}
+
+			if !slices.Equal(g.GetVertices(), want.GetVertices()) {
+				t.Fatalf("got vertices %v, want %v", g.GetVertices(), want.GetVertices())
+			}
+
+			if (g.indices != nil) != (tt.keyFunc != nil) {
+				t.Fatalf("got key index %v, want one only if a key function is set", g.indices)
+			}
+
+			if n := b.Build().VertexCount(); n != 0 {
+				t.Fatalf("got %d vertices after Build, want 0", n)
+			}
+		})
+	}
+}
+
+func TestMaxFlow(t *testing.T) {
+	network := NewGraph(newTestVertices(6), edgeWeights(map[[2]testVertex]float64{
+		{0, 1}: 16,
+		{0, 2}: 13,
+		{2, 1}: 4,
+		{1, 3}: 12,
+		{3, 2}: 9,
+		{2, 4}: 14,
+		{4, 3}: 7,
+		{3, 5}: 20,
+		{4, 5}: 4,
+	}))
+
+	negative := NewGraph(newTestVertices(3), edgeWeights(map[[2]testVertex]float64{
+		{0, 1}: -5,
+		{0, 2}: 3,
+		{2, 1}: 3,
+	}))
+
+	tests := []struct {
+		name         string
+		graph        *Graph[testVertex]
+		source, sink testVertex
+		want         float64
+		wantErr      bool
+	}{
+		{"known network", network, 0, 5, 23, false},
+		{"negative capacity", negative, 0, 1, 3, false},
+		{"no path", negative, 1, 0, 0, false},
+		{"missing source", network, 9, 5, 0, true},
+		{"missing sink", network, 0, 9, 0, true},
+		{"source is sink", network, 0, 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := tt.graph.Clone()
+
+			got, err := tt.graph.MaxFlow(tt.source, tt.sink)
+
+			if !tt.graph.Equals(original) {
+				t.Fatalf("MaxFlow modified the graph")
+			}
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsBipartite(t *testing.T) {
+	evenCycle := edgeWeights(map[[2]testVertex]float64{
+		{0, 1}: 1,
+		{1, 2}: 1,
+		{2, 3}: 1,
+		{3, 0}: 1,
+	})
+
+	tests := []struct {
+		name  string
+		graph *Graph[testVertex]
+		want  bool
+		sets  [2][]testVertex
+	}{
+		{
+			name:  "even cycle",
+			graph: NewGraph(newTestVertices(4), evenCycle),
+			want:  true,
+			sets:  [2][]testVertex{{0, 2}, {1, 3}},
+		},
+		{
+			name:  "undirected even cycle",
+			graph: NewUndirectedGraph(newTestVertices(4), evenCycle),
+			want:  true,
+			sets:  [2][]testVertex{{0, 2}, {1, 3}},
+		},
+		{
+			name: "odd cycle",
+			graph: NewGraph(newTestVertices(3), edgeWeights(map[[2]testVertex]float64{
+				{0, 1}: 1,
+				{1, 2}: 1,
+				{2, 0}: 1,
+			})),
+			want: false,
+		},
+		{
+			name: "self-loop",
+			graph: NewGraph(newTestVertices(2), edgeWeights(map[[2]testVertex]float64{
+				{0, 1}: 1,
+				{1, 1}: 1,
+			})),
+			want: false,
+		},
+		{
+			name: "disconnected",
+			graph: NewGraph(newTestVertices(5), edgeWeights(map[[2]testVertex]float64{
+				{0, 1}: 1,
+				{3, 2}: 1,
+			})),
+			want: true,
+			sets: [2][]testVertex{{0, 2, 4}, {1, 3}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, sets := tt.graph.IsBipartite()
+
+			if got != tt.want {
+				t.Fatalf("got %t, want %t", got, tt.want)
+			}
+
+			for i, set := range sets {
+				if !slices.Equal(set, tt.sets[i]) {
+					t.Errorf("set %d: got %v, want %v", i, set, tt.sets[i])
+				}
+			}
+		})
+	}
+}